@@ -0,0 +1,141 @@
+package bench
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	bot "sweep/bot"
+	gameengine "sweep/game-engine"
+	utils "sweep/shared/utils"
+)
+
+type Options struct {
+	Games  uint16
+	Width  uint16
+	Height uint16
+	Mines  uint16
+	// Seed of the first game, every next game uses the next seed
+	Seed int64
+}
+
+type Result struct {
+	Games    uint16
+	Wins     uint16
+	Guesses  uint64
+	Duration time.Duration
+	// Durations of every game from the fastest to the slowest
+	durations []time.Duration
+}
+
+func (r Result) GetWinRate() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Games) * 100
+}
+
+func (r Result) GetAverageGuesses() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Guesses) / float64(r.Games)
+}
+
+// Returns the duration that percent of the games did not exceed
+func (r Result) GetPercentile(percent int) time.Duration {
+	if len(r.durations) == 0 {
+		return 0
+	}
+	ix := (len(r.durations)*percent+99)/100 - 1
+	return r.durations[min(max(ix, 0), len(r.durations)-1)]
+}
+
+func (r Result) String() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "games     %v\n", r.Games)
+	fmt.Fprintf(&s, "win rate  %.2f%%\n", r.GetWinRate())
+	fmt.Fprintf(&s, "guesses   %.2f per game\n", r.GetAverageGuesses())
+	fmt.Fprintf(&s, "p50       %v\n", r.GetPercentile(50))
+	fmt.Fprintf(&s, "p90       %v\n", r.GetPercentile(90))
+	fmt.Fprintf(&s, "p99       %v\n", r.GetPercentile(99))
+	fmt.Fprintf(&s, "total     %v\n", utils.FormatTime(r.Duration))
+	return s.String()
+}
+
+type gameResult struct {
+	won      bool
+	guesses  uint16
+	duration time.Duration
+}
+
+func createGameEngine(options Options) (*gameengine.GameEngine, error) {
+	gameEngine := &gameengine.GameEngine{}
+	if err := gameEngine.SetFieldSize(options.Width, options.Height); err != nil {
+		return nil, err
+	}
+	if err := gameEngine.SetMineCount(options.Mines); err != nil {
+		return nil, err
+	}
+	return gameEngine, nil
+}
+
+func playGame(options Options, seed int64) gameResult {
+	gameEngine, _ := createGameEngine(options)
+	gameEngine.SetSeed(seed)
+
+	start := time.Now()
+	player := bot.CreateBot(gameEngine, options.Mines, seed)
+	player.Play()
+
+	return gameResult{
+		won:      gameEngine.IsWon(),
+		guesses:  player.GetGuesses(),
+		duration: time.Since(start),
+	}
+}
+
+// Plays the games with the bot on every CPU
+func Run(options Options) (Result, error) {
+	if _, err := createGameEngine(options); err != nil {
+		return Result{}, err
+	}
+
+	start := time.Now()
+	results := make([]gameResult, options.Games)
+
+	seeds := make(chan int, options.Games)
+	for ix := range int(options.Games) {
+		seeds <- ix
+	}
+	close(seeds)
+
+	var wg sync.WaitGroup
+	for range runtime.NumCPU() {
+		wg.Go(func() {
+			for ix := range seeds {
+				results[ix] = playGame(options, options.Seed+int64(ix))
+			}
+		})
+	}
+	wg.Wait()
+
+	result := Result{
+		Games:     options.Games,
+		Duration:  time.Since(start),
+		durations: make([]time.Duration, 0, options.Games),
+	}
+	for _, game := range results {
+		if game.won {
+			result.Wins++
+		}
+		result.Guesses += uint64(game.guesses)
+		result.durations = append(result.durations, game.duration)
+	}
+	slices.Sort(result.durations)
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package bench
+
+import (
+	"testing"
+	"time"
+
+	presets "sweep/shared/consts/presets"
+)
+
+func TestRun(t *testing.T) {
+	preset, _ := presets.GetPreset("beginner")
+	options := Options{Games: 100, Width: preset.Width, Height: preset.Height, Mines: preset.Mines, Seed: 1}
+
+	result, err := Run(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Games != 100 || result.Wins > result.Games || result.Wins == 0 {
+		t.Errorf("[Assertion failed] unexpected result\n%v", result)
+	}
+	if len(result.durations) != 100 {
+		t.Errorf("[Assertion failed]\nExpected 100 durations\nActual: %v", len(result.durations))
+	}
+	if result.GetPercentile(50) > result.GetPercentile(90) || result.GetPercentile(90) > result.GetPercentile(99) {
+		t.Errorf("[Assertion failed] percentiles are not in order\n%v", result)
+	}
+
+	// Games are seeded so the same options always give the same outcome
+	again, err := Run(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Wins != result.Wins || again.Guesses != result.Guesses {
+		t.Errorf("[Assertion failed] results differ for the same seed\nExpected: %v wins, %v guesses\nActual: %v wins, %v guesses", result.Wins, result.Guesses, again.Wins, again.Guesses)
+	}
+
+	if _, err := Run(Options{Games: 1, Width: 2, Height: 2, Mines: 4}); err == nil {
+		t.Errorf("[Assertion failed] expected an error for too many mines")
+	}
+}
+
+func TestGetPercentile(t *testing.T) {
+	type testCase struct {
+		percent  int
+		expected time.Duration
+	}
+
+	result := Result{durations: []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+	testCases := []testCase{
+		{percent: 0, expected: 1},
+		{percent: 50, expected: 5},
+		{percent: 90, expected: 9},
+		{percent: 99, expected: 10},
+		{percent: 100, expected: 10},
+	}
+
+	for _, testCase := range testCases {
+		actual := result.GetPercentile(testCase.percent)
+		if actual != testCase.expected {
+			t.Errorf("[Assertion failed] p%v\nExpected: %v\nActual: %v", testCase.percent, testCase.expected, actual)
+		}
+	}
+}
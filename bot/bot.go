@@ -0,0 +1,235 @@
+package bot
+
+import (
+	"math/rand"
+
+	tilecontent "sweep/shared/consts/tile-content"
+	types "sweep/shared/types"
+)
+
+// Bot plays a game by the tiles a player would see
+// It never looks at the field of the engine, only at the content of the tiles
+type Bot struct {
+	gameEngine types.IGameEngine
+	random     *rand.Rand
+	width      uint16
+	height     uint16
+	mines      uint16
+	guesses    uint16
+}
+
+// A number tile with the closed tiles around it
+type constraint struct {
+	// Mines left around the tile that are not flagged yet
+	mines   int
+	unknown []types.Position
+}
+
+func CreateBot(gameEngine types.IGameEngine, mines uint16, seed int64) *Bot {
+	return &Bot{
+		gameEngine: gameEngine,
+		random:     rand.New(rand.NewSource(seed)),
+		width:      gameEngine.GetWidth(),
+		height:     gameEngine.GetHeight(),
+		mines:      mines,
+	}
+}
+
+// Amount of moves made without a safe deduction, the first move is not counted
+func (b *Bot) GetGuesses() uint16 {
+	return b.guesses
+}
+
+// Plays until the game is finished starting with the center of the field
+func (b *Bot) Play() {
+	center := types.Position{X: b.width / 2, Y: b.height / 2}
+	b.gameEngine.SetMines(center)
+	b.gameEngine.OpenTile(center)
+
+	for !b.gameEngine.IsFinished() {
+		if b.deduce() {
+			continue
+		}
+		b.guesses++
+		b.gameEngine.OpenTile(b.guess())
+	}
+}
+
+func (b *Bot) getNeighbours(position types.Position) []types.Position {
+	neighbours := make([]types.Position, 0, 8)
+	x, y := int(position.X), int(position.Y)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			nx, ny := x+dx, y+dy
+			if (dx == 0 && dy == 0) || nx < 0 || ny < 0 || nx >= int(b.width) || ny >= int(b.height) {
+				continue
+			}
+			neighbours = append(neighbours, types.Position{X: uint16(nx), Y: uint16(ny)})
+		}
+	}
+	return neighbours
+}
+
+// Reads every tile once since counting mines around a tile is not cheap for the engine
+func (b *Bot) readBoard() [][]tilecontent.TileContent {
+	board := make([][]tilecontent.TileContent, b.height)
+	for y := range b.height {
+		board[y] = make([]tilecontent.TileContent, b.width)
+		for x := range b.width {
+			board[y][x] = b.gameEngine.GetTileContent(types.Position{X: x, Y: y})
+		}
+	}
+	return board
+}
+
+func (b *Bot) getConstraints(board [][]tilecontent.TileContent) []constraint {
+	constraints := []constraint{}
+	for y := range b.height {
+		for x := range b.width {
+			count, err := board[y][x].ToNumber()
+			if err != nil {
+				continue
+			}
+
+			c := constraint{mines: int(count)}
+			for _, neighbour := range b.getNeighbours(types.Position{X: x, Y: y}) {
+				switch board[neighbour.Y][neighbour.X] {
+				case tilecontent.Empty:
+					c.unknown = append(c.unknown, neighbour)
+				case tilecontent.Flag, tilecontent.Mine:
+					c.mines--
+				}
+			}
+			if len(c.unknown) > 0 {
+				constraints = append(constraints, c)
+			}
+		}
+	}
+	return constraints
+}
+
+// Makes every safe move it can find, returns whether any was made
+func (b *Bot) deduce() bool {
+	constraints := b.getConstraints(b.readBoard())
+	safe := map[types.Position]struct{}{}
+	mines := map[types.Position]struct{}{}
+
+	mark := func(positions []types.Position, mineCount int) {
+		switch mineCount {
+		case 0:
+			for _, position := range positions {
+				safe[position] = struct{}{}
+			}
+		case len(positions):
+			for _, position := range positions {
+				mines[position] = struct{}{}
+			}
+		}
+	}
+
+	for _, c := range constraints {
+		mark(c.unknown, c.mines)
+	}
+
+	// When the closed tiles of one number are a part of closed tiles of another
+	// the mines of the difference are the difference of the mines
+	if len(safe) == 0 && len(mines) == 0 {
+		for _, a := range constraints {
+			for _, c := range constraints {
+				if difference, ok := subtract(c.unknown, a.unknown); ok && len(difference) > 0 {
+					mark(difference, c.mines-a.mines)
+				}
+			}
+		}
+	}
+
+	for position := range mines {
+		b.gameEngine.FlagToggleTile(position)
+	}
+	for position := range safe {
+		if b.gameEngine.IsFinished() {
+			break
+		}
+		b.gameEngine.OpenTile(position)
+	}
+
+	return len(safe) > 0 || len(mines) > 0
+}
+
+// Returns the tiles of a that are not in b if b is a subset of a
+func subtract(a, b []types.Position) ([]types.Position, bool) {
+	difference := []types.Position{}
+	for _, position := range a {
+		found := false
+		for _, other := range b {
+			if position == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			difference = append(difference, position)
+		}
+	}
+	return difference, len(a)-len(difference) == len(b)
+}
+
+// Picks the closed tile that is the least likely to be a mine
+// Tiles next to numbers take the worst chance of the numbers around them,
+// the rest share the mines that are left evenly
+func (b *Bot) guess() types.Position {
+	board := b.readBoard()
+	constraints := b.getConstraints(board)
+	chances := map[types.Position]float64{}
+	for _, c := range constraints {
+		chance := float64(c.mines) / float64(len(c.unknown))
+		for _, position := range c.unknown {
+			chances[position] = max(chances[position], chance)
+		}
+	}
+
+	var flags int
+	closed := []types.Position{}
+	for y := range b.height {
+		for x := range b.width {
+			position := types.Position{X: x, Y: y}
+			switch board[y][x] {
+			case tilecontent.Empty:
+				closed = append(closed, position)
+			case tilecontent.Flag, tilecontent.Mine:
+				flags++
+			}
+		}
+	}
+
+	var expected float64
+	for _, chance := range chances {
+		expected += chance
+	}
+	if rest := len(closed) - len(chances); rest > 0 {
+		restChance := max(float64(int(b.mines)-flags)-expected, 0) / float64(rest)
+		for _, position := range closed {
+			if _, ok := chances[position]; !ok {
+				chances[position] = restChance
+			}
+		}
+	}
+
+	// Ties are broken randomly so the bot does not always probe the same corner
+	best := closed[0]
+	bestChance := 2.0
+	ties := 0
+	for _, position := range closed {
+		chance := chances[position]
+		switch {
+		case chance < bestChance:
+			best, bestChance, ties = position, chance, 1
+		case chance == bestChance:
+			ties++
+			if b.random.Intn(ties) == 0 {
+				best = position
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"testing"
+
+	gameengine "sweep/game-engine"
+	tiles "sweep/shared/consts/tiles"
+)
+
+// Deductions have to be sound, so the bot never flags a safe tile
+// and loses only by guessing
+func TestPlay(t *testing.T) {
+	for seed := range int64(200) {
+		gameEngine := &gameengine.GameEngine{}
+		gameEngine.SetFieldSize(16, 16)
+		gameEngine.SetMineCount(40)
+		gameEngine.SetSeed(seed)
+
+		bot := CreateBot(gameEngine, 40, seed)
+		bot.Play()
+
+		if !gameEngine.IsFinished() {
+			t.Fatalf("[Assertion failed] seed %v: game is not finished", seed)
+		}
+		if !gameEngine.IsWon() && bot.GetGuesses() == 0 {
+			t.Errorf("[Assertion failed] seed %v: game is lost without a single guess", seed)
+		}
+		for y, row := range gameEngine.GetField() {
+			for x, tile := range row {
+				if tile == tiles.FlaggedSafe {
+					t.Errorf("[Assertion failed] seed %v: safe tile at x: %v, y: %v is flagged", seed, x, y)
+				}
+			}
+		}
+	}
+}
@@ -2,6 +2,9 @@ package bindings
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	actions "sweep/shared/consts/actions"
 	regexes "sweep/shared/vars/regexes"
@@ -9,9 +12,40 @@ import (
 
 type Bindings map[actions.ActionType][]string
 
+// Actions are gone through in the same order every time so the errors
+// and the action taking a key bound more than once do not change between runs
+func (b Bindings) getActions() []actions.ActionType {
+	return slices.Sorted(maps.Keys(b))
+}
+
+// Global actions used to be taken with keys fixed in the screens
+// so the configs not binding them keep these keys
+var defaultBindings = Bindings{
+	actions.Quit:             []string{"ctrl+c", "q"},
+	actions.ClearKeyBuffer:   []string{"esc"},
+	actions.RestartSameBoard: []string{"ctrl+r"},
+	actions.NewGame:          []string{"ctrl+n", "enter"},
+	actions.MainMenu:         []string{"backspace"},
+}
+
+// Adds the default keys of the global actions missing from the bindings, an empty list leaves an action unbound
+func (b Bindings) withDefaults() Bindings {
+	result := maps.Clone(b)
+	if result == nil {
+		result = Bindings{}
+	}
+	for action, keys := range defaultBindings {
+		if _, ok := result[action]; !ok {
+			result[action] = keys
+		}
+	}
+	return result
+}
+
 func (b Bindings) Apply() {
-	for action, bindings := range b {
-		for _, key := range bindings {
+	b = b.withDefaults()
+	for _, action := range b.getActions() {
+		for _, key := range b[action] {
 			action.SetBinding(key)
 		}
 	}
@@ -36,24 +70,192 @@ type InvalidKeyPressPatternError struct {
 }
 
 func (e *InvalidKeyPressPatternError) Error() string {
-	return fmt.Sprintf("(bindings.%v.%v) %v does not match key press pattern", e.action, e.index, e.binding)
+	return fmt.Sprintf("(bindings.%v.%v) %v does not match key press or mouse button pattern", e.action, e.index, e.binding)
 }
 
 func (e *InvalidKeyPressPatternError) Is(target error) bool {
 	return e.Error() == target.Error()
 }
 
+type DuplicateBindingError struct {
+	binding string
+	actions []actions.ActionType
+}
+
+func (e *DuplicateBindingError) Error() string {
+	names := make([]string, len(e.actions))
+	for ix, action := range e.actions {
+		names[ix] = string(action)
+	}
+	return fmt.Sprintf("(bindings) \"%v\" is bound to %v while only one of them could take it: remove it from all but one", e.binding, strings.Join(names, ", "))
+}
+
+func (e *DuplicateBindingError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+// Warnings are not errors as the bindings still work, they only show what may be unexpected about them
+
+type PrefixBindingWarning struct {
+	action       actions.ActionType
+	binding      string
+	longerAction actions.ActionType
+	longer       string
+}
+
+func (e *PrefixBindingWarning) Error() string {
+	if e.action.TakesRegister() {
+		return fmt.Sprintf("(bindings.%v) \"%v\" takes a register so \"%v\" is taken for it instead of %v: bind %v to keys not starting with \"%v\"", e.action, e.binding, e.longer, e.longerAction, e.longerAction, e.binding)
+	}
+	return fmt.Sprintf("(bindings.%v) \"%v\" is the start of \"%v\" of %v so it waits for the next key until the timeoutlen is over: bind it to keys no other binding starts with to take it at once", e.action, e.binding, e.longer, e.longerAction)
+}
+
+func (e *PrefixBindingWarning) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type QuantifierBindingWarning struct {
+	action  actions.ActionType
+	binding string
+}
+
+func (e *QuantifierBindingWarning) Error() string {
+	return fmt.Sprintf("(bindings.%v) \"%v\" starts with a digit so it is read as a part of the quantifier when typed after one: start it with another key to use it with quantifiers", e.action, e.binding)
+}
+
+func (e *QuantifierBindingWarning) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
 func (b Bindings) Validate() (bool, []error) {
 	var errors []error
-	for action, bindings := range b {
+	for _, action := range b.getActions() {
 		if !actions.IsAction(string(action)) {
 			errors = append(errors, &InvalidActionError{action})
 		}
-		for index, binding := range bindings {
-			if !regexes.KeyPressRegex.MatchString(binding) {
+		for index, binding := range b[action] {
+			if !regexes.KeyPressRegex.MatchString(binding) && !regexes.MouseButtonRegex.MatchString(binding) {
 				errors = append(errors, &InvalidKeyPressPatternError{action, index, binding})
 			}
 		}
 	}
+	errors = append(errors, b.getDuplicates()...)
 	return len(errors) == 0, errors
 }
+
+// Lists the actions every key is bound to, an action binding the same key twice counts once
+func (b Bindings) getBoundActions() map[string][]actions.ActionType {
+	b = b.withDefaults()
+	boundActions := map[string][]actions.ActionType{}
+	for _, action := range b.getActions() {
+		for _, binding := range b[action] {
+			if !slices.Contains(boundActions[binding], action) {
+				boundActions[binding] = append(boundActions[binding], action)
+			}
+		}
+	}
+	return boundActions
+}
+
+// A key bound to a global action and to an action of the screen is fine as the latter takes it
+// so only the actions of the same kind get in the way of each other
+func (b Bindings) getDuplicates() []error {
+	var errors []error
+	boundActions := b.getBoundActions()
+	for _, binding := range slices.Sorted(maps.Keys(boundActions)) {
+		global, screen := splitGlobal(boundActions[binding])
+		if len(screen) > 1 {
+			errors = append(errors, &DuplicateBindingError{binding, screen})
+		}
+		if len(global) > 1 {
+			errors = append(errors, &DuplicateBindingError{binding, global})
+		}
+	}
+	return errors
+}
+
+func splitGlobal(bound []actions.ActionType) ([]actions.ActionType, []actions.ActionType) {
+	var global, screen []actions.ActionType
+	for _, action := range bound {
+		if action.IsGlobal() {
+			global = append(global, action)
+		} else {
+			screen = append(screen, action)
+		}
+	}
+	return global, screen
+}
+
+// The action the key is taken for in the game
+func resolve(bound []actions.ActionType) actions.ActionType {
+	global, screen := splitGlobal(bound)
+	if len(screen) > 0 {
+		return screen[0]
+	}
+	return global[0]
+}
+
+// Finds the bindings that are valid but get in the way of each other or of the quantifiers
+func (b Bindings) Warnings() []error {
+	var warnings []error
+	boundActions := b.getBoundActions()
+	bindings := slices.Sorted(maps.Keys(boundActions))
+
+	for _, binding := range bindings {
+		if regexes.MouseButtonRegex.MatchString(binding) {
+			continue
+		}
+		action := resolve(boundActions[binding])
+		if binding[0] >= '0' && binding[0] <= '9' {
+			warnings = append(warnings, &QuantifierBindingWarning{action, binding})
+		}
+		for _, longer := range bindings {
+			longerAction := resolve(boundActions[longer])
+			if longer == binding || longerAction == action || regexes.MouseButtonRegex.MatchString(longer) || !actions.IsKeysPrefix(binding, longer) {
+				continue
+			}
+			warnings = append(warnings, &PrefixBindingWarning{action, binding, longerAction, longer})
+		}
+	}
+	return warnings
+}
+
+// Lists the keymap the bindings resolve to with the errors and the warnings found in them
+func (b Bindings) Report() (string, bool) {
+	isValid, errors := b.Validate()
+	warnings := b.Warnings()
+	b.Apply()
+
+	var s strings.Builder
+	for ix, context := range actions.GetContexts() {
+		keymap := actions.GetScreenKeymap(context)
+		keysWidth := 0
+		for _, binding := range keymap {
+			keysWidth = max(keysWidth, len(binding.Keys))
+		}
+		if ix > 0 {
+			s.WriteRune('\n')
+		}
+		fmt.Fprintf(&s, "Keymap (%v):\n", context)
+		for _, binding := range keymap {
+			fmt.Fprintf(&s, "  %-*v  %v\n", keysWidth, binding.Keys, binding.Kind)
+		}
+	}
+
+	if len(warnings) > 0 {
+		s.WriteString("\nWarnings:\n")
+		for _, warning := range warnings {
+			fmt.Fprintf(&s, "  %v\n", warning)
+		}
+	}
+	if len(errors) > 0 {
+		s.WriteString("\nErrors:\n")
+		for _, err := range errors {
+			fmt.Fprintf(&s, "  %v\n", err)
+		}
+	}
+	if len(warnings) == 0 && len(errors) == 0 {
+		s.WriteString("\nNo conflicts found\n")
+	}
+	return s.String(), isValid
+}
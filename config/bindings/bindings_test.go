@@ -87,6 +87,75 @@ func Test_Validate(t *testing.T) {
 			},
 			errs: []error{},
 		},
+		{
+			isValid: true,
+			bindings: Bindings{
+				actions.OpenTile:  []string{"left press"},
+				actions.ChordTile: []string{"ctrl+right press"},
+			},
+			errs: []error{},
+		},
+		{
+			isValid: false,
+			bindings: Bindings{
+				actions.FlagTile: []string{"right click"},
+			},
+			errs: []error{
+				&InvalidKeyPressPatternError{
+					action:  actions.FlagTile,
+					index:   0,
+					binding: "right click",
+				},
+			},
+		},
+		{
+			isValid: false,
+			bindings: Bindings{
+				actions.OpenTile: []string{"z", "x"},
+				actions.FlagTile: []string{"x"},
+			},
+			errs: []error{
+				&DuplicateBindingError{
+					binding: "x",
+					actions: []actions.ActionType{actions.FlagTile, actions.OpenTile},
+				},
+			},
+		},
+		{
+			isValid: true,
+			bindings: Bindings{
+				actions.JumpForward: []string{"tab", "tab"},
+			},
+			errs: []error{},
+		},
+		{
+			isValid: true,
+			bindings: Bindings{
+				actions.RecordMacro: []string{"q"},
+				actions.OpenTile:    []string{"enter"},
+			},
+			errs: []error{},
+		},
+		{
+			isValid: false,
+			bindings: Bindings{
+				actions.MainMenu: []string{"q"},
+			},
+			errs: []error{
+				&DuplicateBindingError{
+					binding: "q",
+					actions: []actions.ActionType{actions.MainMenu, actions.Quit},
+				},
+			},
+		},
+		{
+			isValid: true,
+			bindings: Bindings{
+				actions.MainMenu: []string{"q"},
+				actions.Quit:     []string{},
+			},
+			errs: []error{},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -114,6 +183,60 @@ func Test_Validate(t *testing.T) {
 	}
 }
 
+func Test_Warnings(t *testing.T) {
+	type TestCase struct {
+		bindings Bindings
+		warnings []error
+	}
+
+	testCases := []TestCase{
+		{
+			bindings: Bindings{
+				actions.MoveCursorToTopRow:    []string{"gg"},
+				actions.MoveCursorToBottomRow: []string{"g", "G"},
+			},
+			warnings: []error{
+				&PrefixBindingWarning{actions.MoveCursorToBottomRow, "g", actions.MoveCursorToTopRow, "gg"},
+			},
+		},
+		{
+			bindings: Bindings{
+				actions.SetMark:  []string{"m"},
+				actions.OpenTile: []string{"ma"},
+			},
+			warnings: []error{
+				&PrefixBindingWarning{actions.SetMark, "m", actions.OpenTile, "ma"},
+			},
+		},
+		{
+			bindings: Bindings{
+				actions.MoveCursorToFirstColumn: []string{"0"},
+				actions.MoveCursorToTopRow:      []string{"gg", "g"},
+				actions.MoveCursorRight:         []string{"l"},
+				actions.ScrollLeft:              []string{"alt+h"},
+				actions.MoveCursorLeft:          []string{"a"},
+				actions.OpenTile:                []string{"left press"},
+			},
+			warnings: []error{
+				&QuantifierBindingWarning{actions.MoveCursorToFirstColumn, "0"},
+			},
+		},
+	}
+
+	for n, testCase := range testCases {
+		warnings := testCase.bindings.Warnings()
+
+		areWarningsEqual := len(warnings) == len(testCase.warnings)
+		for ix := 0; areWarningsEqual && ix < len(warnings); ix++ {
+			areWarningsEqual = errors.Is(warnings[ix], testCase.warnings[ix])
+		}
+
+		if !areWarningsEqual {
+			t.Errorf("[Assertion failed] #%v Warnings should be equal\nExpected: %v\nActual: %v\n", n+1, testCase.warnings, warnings)
+		}
+	}
+}
+
 func Test_Apply(t *testing.T) {
 	type TestCase struct {
 		bindings Bindings
@@ -145,3 +268,36 @@ func Test_Apply(t *testing.T) {
 		}
 	}
 }
+
+func Test_ApplyDefaults(t *testing.T) {
+	type TestCase struct {
+		context  actions.Context
+		key      string
+		expected actions.ActionType
+		ok       bool
+	}
+
+	Bindings{
+		actions.RecordMacro: []string{"q"},
+		actions.MainMenu:    []string{"m"},
+		actions.NewGame:     []string{},
+	}.Apply()
+
+	testCases := []TestCase{
+		{context: actions.EndScreenContext, key: "q", expected: actions.Quit, ok: true},
+		{context: actions.StartScreenContext, key: "ctrl+c", expected: actions.Quit, ok: true},
+		{context: actions.EndScreenContext, key: "m", expected: actions.MainMenu, ok: true},
+		{context: actions.EndScreenContext, key: "ctrl+r", expected: actions.RestartSameBoard, ok: true},
+	}
+
+	for n, testCase := range testCases {
+		actual, ok := actions.GetScreenAction(testCase.context, testCase.key)
+		if ok != testCase.ok || actual != testCase.expected {
+			t.Errorf("[Assertion failed] #%v\nExpected: %v %v\nActual: %v %v\n", n+1, testCase.expected, testCase.ok, actual, ok)
+		}
+	}
+
+	if !actions.IsBinding("q", actions.RecordMacro) || !actions.IsBinding("esc", actions.ClearKeyBuffer) {
+		t.Errorf("[Assertion failed] the game should record macros with \"q\" and clear the keys with \"esc\"")
+	}
+}
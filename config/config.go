@@ -8,24 +8,27 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
 	bindings "sweep/config/bindings"
 	colors "sweep/config/colors"
 	cursor "sweep/config/cursor"
 	flags "sweep/config/flags"
 	glyphs "sweep/config/glyphs"
+	densities "sweep/shared/consts/densities"
 	envkeys "sweep/shared/consts/env-keys"
+	presets "sweep/shared/consts/presets"
+	winconditions "sweep/shared/consts/win-conditions"
 	paths "sweep/shared/vars/paths"
 	themepreview "sweep/tui/theme-preview"
 
 	gojsonschema "github.com/xeipuuv/gojsonschema"
 )
 
-var schema *any
-
-func init() {
-	schema = loadSchema(paths.ConfigSchemaPath)
-}
+// The schema is read on the first validation so the packages using the config could be tested without it
+var getSchema = sync.OnceValue(func() *any {
+	return loadSchema(paths.ConfigSchemaPath)
+})
 
 type Defaults struct {
 	Width  uint16
@@ -44,6 +47,43 @@ type Config struct {
 	Mines  uint16 `json:"mines,omitempty"`
 	Width  uint16 `json:"width,omitempty"`
 	Height uint16 `json:"height,omitempty"`
+	Lives  uint16 `json:"lives,omitempty"`
+
+	Players uint16 `json:"players,omitempty"`
+
+	TimeLimit uint16 `json:"time limit,omitempty"`
+	TimeBonus uint16 `json:"time bonus,omitempty"`
+
+	WinCondition winconditions.WinCondition `json:"win condition,omitempty"`
+	NoFlag       bool                       `json:"no flag,omitempty"`
+	Seed         int64                      `json:"seed,omitempty"`
+
+	// How many tiles are kept between the cursor and the edge of the screen when the field scrolls
+	ScrollMargin uint16 `json:"scroll margin,omitempty"`
+	// How many cells a tile takes on the screen
+	Density densities.Density `json:"density,omitempty"`
+	// How many cells the longest side of the minimap takes
+	MinimapSize uint16 `json:"minimap size,omitempty"`
+	// Shows the marks in a column left of the field
+	MarkGutter bool `json:"mark gutter,omitempty"`
+	// How many milliseconds a binding waits for the keys of a longer one starting with it
+	Timeoutlen uint16 `json:"timeoutlen,omitempty"`
+
+	// Looks set with :set for the games of a single session, the flags of the program change them for every session
+	Fill  bool `json:"-"`
+	ASCII bool `json:"-"`
+
+	// Modes set only with the command line
+	RaceHost  string `json:"-"`
+	RaceJoin  string `json:"-"`
+	CoopHost  string `json:"-"`
+	CoopJoin  string `json:"-"`
+	ServeSSH  string `json:"-"`
+	ServeHTTP string `json:"-"`
+	Headless  bool   `json:"-"`
+	Bench     bool   `json:"-"`
+	// Amount of games played by the bot in the benchmark
+	BenchGames uint16 `json:"-"`
 }
 
 type ConfigValidationError struct {
@@ -67,10 +107,66 @@ func (e *GoJsonSchemaConfigValidationError) Error() string {
 	return e.err.String()
 }
 
+type InvalidWinConditionError struct {
+	winCondition winconditions.WinCondition
+}
+
+func (e *InvalidWinConditionError) Error() string {
+	return fmt.Sprintf("(win condition) %v is not a valid win condition", e.winCondition)
+}
+
+func (e *InvalidWinConditionError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidDensityError struct {
+	density densities.Density
+}
+
+func (e *InvalidDensityError) Error() string {
+	return fmt.Sprintf("(density) %v is not a valid density", e.density)
+}
+
+func (e *InvalidDensityError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type StrictNoFlagError struct{}
+
+func (e *StrictNoFlagError) Error() string {
+	return "(win condition) strict needs every mine flagged so it can not be played with no flag"
+}
+
+func (e *StrictNoFlagError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+// The strict win condition can not be met without flags
+func (config *Config) ValidateModes() error {
+	if config.NoFlag && config.WinCondition == winconditions.Strict {
+		return &StrictNoFlagError{}
+	}
+	return nil
+}
+
+const MaxPlayers uint16 = 4
+
+type TooManyPlayersError struct {
+	players uint16
+}
+
+func (e *TooManyPlayersError) Error() string {
+	return fmt.Sprintf("(players) %v players can not share the field, the maximum is %v", e.players, MaxPlayers)
+}
+
+func (e *TooManyPlayersError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
 func (config *Config) Validate() (bool, []error) {
 	configLoader := gojsonschema.NewGoLoader(config)
 
-	schemaLoader := gojsonschema.NewGoLoader(schema)
+	schemaLoader := gojsonschema.NewGoLoader(getSchema())
 
 	errors := []error{}
 
@@ -107,6 +203,22 @@ func (config *Config) Validate() (bool, []error) {
 		errors = append(errors, glyphsErrors...)
 	}
 
+	if config.Players > MaxPlayers {
+		errors = append(errors, &TooManyPlayersError{config.Players})
+	}
+
+	if config.WinCondition != "" && !winconditions.IsWinCondition(string(config.WinCondition)) {
+		errors = append(errors, &InvalidWinConditionError{config.WinCondition})
+	}
+
+	if config.Density != "" && !densities.IsDensity(string(config.Density)) {
+		errors = append(errors, &InvalidDensityError{config.Density})
+	}
+
+	if err := config.ValidateModes(); err != nil {
+		errors = append(errors, err)
+	}
+
 	return len(errors) == 0, errors
 }
 
@@ -135,6 +247,117 @@ func (config *Config) Apply() {
 		parsed, _ := strconv.ParseUint(val, 10, 16)
 		config.Mines = uint16(parsed)
 	}
+	if val, ok := os.LookupEnv(envkeys.Lives); ok {
+		parsed, _ := strconv.ParseUint(val, 10, 16)
+		config.Lives = uint16(parsed)
+	}
+	if val, ok := os.LookupEnv(envkeys.Players); ok {
+		parsed, _ := strconv.ParseUint(val, 10, 16)
+		config.Players = uint16(parsed)
+	}
+	if val, ok := os.LookupEnv(envkeys.TimeLimit); ok {
+		parsed, _ := strconv.ParseUint(val, 10, 16)
+		config.TimeLimit = uint16(parsed)
+	}
+	if val, ok := os.LookupEnv(envkeys.TimeBonus); ok {
+		parsed, _ := strconv.ParseUint(val, 10, 16)
+		config.TimeBonus = uint16(parsed)
+	}
+	if val, ok := os.LookupEnv(envkeys.WinCondition); ok {
+		config.WinCondition = winconditions.WinCondition(val)
+	}
+	if val, ok := os.LookupEnv(envkeys.NoFlag); ok && val == "true" {
+		config.NoFlag = true
+	}
+	if val, ok := os.LookupEnv(envkeys.Seed); ok {
+		parsed, _ := strconv.ParseInt(val, 10, 64)
+		config.Seed = parsed
+	}
+	if val, ok := os.LookupEnv(envkeys.RaceHost); ok {
+		config.RaceHost = val
+	}
+	if val, ok := os.LookupEnv(envkeys.RaceJoin); ok {
+		config.RaceJoin = val
+	}
+	if val, ok := os.LookupEnv(envkeys.CoopHost); ok {
+		config.CoopHost = val
+	}
+	if val, ok := os.LookupEnv(envkeys.CoopJoin); ok {
+		config.CoopJoin = val
+	}
+	if val, ok := os.LookupEnv(envkeys.ServeSSH); ok {
+		config.ServeSSH = val
+	}
+	if val, ok := os.LookupEnv(envkeys.ServeHTTP); ok {
+		config.ServeHTTP = val
+	}
+	if val, ok := os.LookupEnv(envkeys.Headless); ok && val == "true" {
+		config.Headless = true
+	}
+	if val, ok := os.LookupEnv(envkeys.Bench); ok && val == "true" {
+		config.Bench = true
+	}
+	if val, ok := os.LookupEnv(envkeys.BenchGames); ok {
+		parsed, _ := strconv.ParseUint(val, 10, 16)
+		config.BenchGames = uint16(parsed)
+	}
+	if config.WinCondition == "" {
+		config.WinCondition = winconditions.Classic
+	}
+	if config.Density == "" {
+		config.Density = densities.Normal
+	}
+}
+
+// Sets the options of the flags on a copy of the config without touching the environment or the styles
+// so the other sessions of the process keep their own, the flags are expected to be validated
+func (config Config) WithFlags(commandFlags flags.Flags) Config {
+	parseUint16 := func(ix int) uint16 {
+		parsed, _ := strconv.ParseUint(commandFlags[ix+1], 10, 16)
+		return uint16(parsed)
+	}
+
+	for ix := 0; ix < len(commandFlags); ix++ {
+		switch commandFlags[ix] {
+		case flags.WIDTH, flags.WIDTH_SHORT:
+			config.Width = parseUint16(ix)
+			ix++
+		case flags.HEIGHT, flags.HEIGHT_SHORT:
+			config.Height = parseUint16(ix)
+			ix++
+		case flags.MINES, flags.MINES_SHORT:
+			config.Mines = parseUint16(ix)
+			ix++
+		case flags.LIVES, flags.LIVES_SHORT:
+			config.Lives = parseUint16(ix)
+			ix++
+		case flags.PLAYERS, flags.PLAYERS_SHORT:
+			config.Players = parseUint16(ix)
+			ix++
+		case flags.TIME_LIMIT, flags.TIME_LIMIT_SHORT:
+			config.TimeLimit = parseUint16(ix)
+			ix++
+		case flags.TIME_BONUS, flags.TIME_BONUS_SHORT:
+			config.TimeBonus = parseUint16(ix)
+			ix++
+		case flags.SEED, flags.SEED_SHORT:
+			config.Seed, _ = strconv.ParseInt(commandFlags[ix+1], 10, 64)
+			ix++
+		case flags.PRESET:
+			preset, _ := presets.GetPreset(commandFlags[ix+1])
+			config.Width, config.Height, config.Mines = preset.Width, preset.Height, preset.Mines
+			ix++
+		case flags.STRICT, flags.STRICT_SHORT:
+			config.WinCondition = winconditions.Strict
+		case flags.NO_FLAG, flags.NO_FLAG_SHORT:
+			config.NoFlag = true
+		case flags.FILL, flags.FILL_SHORT:
+			config.Fill = true
+		case flags.ASCII, flags.ASCII_SHORT:
+			config.ASCII = true
+		}
+	}
+	return config
 }
 
 func loadSchema(schemaPath string) *any {
@@ -227,6 +450,15 @@ func LoadConfig(options *loadConfigOpts) (*Config, error) {
 		config = options.config
 	}
 
+	if val, ok := os.LookupEnv(envkeys.CheckBindings); ok && val == "true" {
+		report, isValid := config.Bindings.Report()
+		fmt.Print(report)
+		if !isValid {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	isValid, errors := config.Validate()
 
 	if !isValid {
@@ -235,6 +467,11 @@ func LoadConfig(options *loadConfigOpts) (*Config, error) {
 
 	config.Apply()
 
+	// The flags may turn on the mode the config file does not conflict with
+	if err := config.ValidateModes(); err != nil {
+		return nil, &ConfigValidationError{[]error{err}}
+	}
+
 	return config, nil
 }
 
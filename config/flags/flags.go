@@ -4,11 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
 	envkeys "sweep/shared/consts/env-keys"
 	consts "sweep/shared/consts/misc"
+	presets "sweep/shared/consts/presets"
 	tilecontent "sweep/shared/consts/tile-content"
+	winconditions "sweep/shared/consts/win-conditions"
 	types "sweep/shared/types"
 	paths "sweep/shared/vars/paths"
 	styles "sweep/tui/styles"
@@ -35,6 +39,27 @@ const (
 	MINES       types.Flag = "--mines"
 	MINES_SHORT types.Flag = "--M"
 
+	LIVES       types.Flag = "--lives"
+	LIVES_SHORT types.Flag = "--L"
+
+	PLAYERS       types.Flag = "--players"
+	PLAYERS_SHORT types.Flag = "--U"
+
+	TIME_LIMIT       types.Flag = "--time-limit"
+	TIME_LIMIT_SHORT types.Flag = "--T"
+
+	TIME_BONUS       types.Flag = "--time-bonus"
+	TIME_BONUS_SHORT types.Flag = "--B"
+
+	STRICT       types.Flag = "--strict"
+	STRICT_SHORT types.Flag = "--S"
+
+	NO_FLAG       types.Flag = "--no-flag"
+	NO_FLAG_SHORT types.Flag = "--N"
+
+	SEED       types.Flag = "--seed"
+	SEED_SHORT types.Flag = "--R"
+
 	FILL       types.Flag = "--fill"
 	FILL_SHORT types.Flag = "--F"
 
@@ -42,6 +67,31 @@ const (
 	DEFAULT_CONFIG_SHORT types.Flag = "--D"
 
 	HELP types.Flag = "--help"
+
+	HEADLESS types.Flag = "--headless"
+
+	PRESET types.Flag = "--preset"
+
+	// Commands for the race mode
+	HOST types.Flag = "host"
+	JOIN types.Flag = "join"
+
+	// Commands for the co-op mode
+	COOP_HOST types.Flag = "coop-host"
+	COOP_JOIN types.Flag = "coop-join"
+
+	// Command for the server mode and its options
+	SERVE types.Flag = "serve"
+	SSH   types.Flag = "--ssh"
+	HTTP  types.Flag = "--http"
+
+	// Command for the benchmark of the bot and its options
+	BENCH types.Flag = "bench"
+	GAMES types.Flag = "--games"
+
+	// Command for the tools of the config and its subcommands
+	CONFIG_COMMAND types.Flag = "config"
+	CHECK_BINDINGS types.Flag = "check-bindings"
 )
 
 type NoArgumentProvidedFlagError struct {
@@ -56,6 +106,42 @@ func (e *NoArgumentProvidedFlagError) Is(target error) bool {
 	return e.Error() == target.Error()
 }
 
+type NoServerProvidedFlagError struct {
+	flag types.Flag
+}
+
+func (e *NoServerProvidedFlagError) Error() string {
+	return fmt.Sprintf("\"%v\" requires a server to be provided with \"%v\" or \"%v\"", e.flag, SSH, HTTP)
+}
+
+func (e *NoServerProvidedFlagError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidPresetFlagError struct {
+	preset string
+}
+
+func (e *InvalidPresetFlagError) Error() string {
+	return fmt.Sprintf("argument for flag \"%v\" must be one of beginner, intermediate or expert, got \"%v\"", PRESET, e.preset)
+}
+
+func (e *InvalidPresetFlagError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidConfigCommandError struct {
+	subcommand string
+}
+
+func (e *InvalidConfigCommandError) Error() string {
+	return fmt.Sprintf("\"%v\" must be followed by \"%v\", got \"%v\"", CONFIG_COMMAND, CHECK_BINDINGS, e.subcommand)
+}
+
+func (e *InvalidConfigCommandError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
 type MustBeUin16FlagError struct {
 	flag types.Flag
 }
@@ -84,6 +170,39 @@ func validateFlagUint16Argument(args []string, index int) error {
 	return nil
 }
 
+type MustBeInt64FlagError struct {
+	flag types.Flag
+}
+
+func (e *MustBeInt64FlagError) Error() string {
+	return fmt.Sprintf("argument for flag \"%v\" must be a signed 64 bit integer", e.flag)
+}
+
+func (e *MustBeInt64FlagError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+func validateFlagInt64Argument(args []string, index int) error {
+	flag := args[index]
+
+	if index+1 >= len(args) {
+		return &NoArgumentProvidedFlagError{flag}
+	}
+	val := args[index+1]
+	_, err := strconv.ParseInt(val, 10, 64)
+
+	if err != nil {
+		return &MustBeInt64FlagError{flag}
+	}
+
+	return nil
+}
+
+// Whether the flag at index is followed by an argument instead of another flag
+func hasFlagArgument(args []string, index int) bool {
+	return index+1 < len(args) && !strings.HasPrefix(args[index+1], "--")
+}
+
 func getFlagArgument(args []string, index int) string {
 	return args[index+1]
 }
@@ -113,17 +232,54 @@ func (f Flags) Validate() (bool, []error) {
 		}
 
 		switch arg {
-		case HEIGHT, HEIGHT_SHORT, WIDTH, WIDTH_SHORT, MINES, MINES_SHORT:
+		case HEIGHT, HEIGHT_SHORT, WIDTH, WIDTH_SHORT, MINES, MINES_SHORT, LIVES, LIVES_SHORT,
+			PLAYERS, PLAYERS_SHORT, GAMES,
+			TIME_LIMIT, TIME_LIMIT_SHORT, TIME_BONUS, TIME_BONUS_SHORT:
 			skip = true
 
 			if err := validateFlagUint16Argument(flagList, ix); err != nil {
 				errors = append(errors, err)
 			}
+		case SEED, SEED_SHORT:
+			skip = true
+
+			if err := validateFlagInt64Argument(flagList, ix); err != nil {
+				errors = append(errors, err)
+			}
+		case PRESET:
+			skip = true
+
+			if ix+1 >= len(flagList) {
+				errors = append(errors, &NoArgumentProvidedFlagError{arg})
+			} else if !presets.IsPreset(flagList[ix+1]) {
+				errors = append(errors, &InvalidPresetFlagError{flagList[ix+1]})
+			}
+		case HOST, COOP_HOST:
+			skip = hasFlagArgument(flagList, ix)
+		case JOIN, COOP_JOIN, SSH, HTTP:
+			skip = true
+
+			if ix+1 >= len(flagList) {
+				errors = append(errors, &NoArgumentProvidedFlagError{arg})
+			}
+		case SERVE:
+			if !slices.Contains(flagList, SSH) && !slices.Contains(flagList, HTTP) {
+				errors = append(errors, &NoServerProvidedFlagError{arg})
+			}
+		case CONFIG_COMMAND:
+			skip = true
+
+			if ix+1 >= len(flagList) {
+				errors = append(errors, &NoArgumentProvidedFlagError{arg})
+			} else if flagList[ix+1] != CHECK_BINDINGS {
+				errors = append(errors, &InvalidConfigCommandError{flagList[ix+1]})
+			}
 		case ASCII, ASCII_SHORT,
-			FILL, FILL_SHORT, CONFIG, CONFIG_SHORT,
+			FILL, FILL_SHORT, STRICT, STRICT_SHORT,
+			NO_FLAG, NO_FLAG_SHORT, CONFIG, CONFIG_SHORT,
 			THEME_PREVIEW, THEME_PREVIEW_SHORT,
 			DEFAULT_CONFIG, DEFAULT_CONFIG_SHORT,
-			HELP:
+			HELP, HEADLESS, BENCH:
 
 			continue
 		default:
@@ -205,35 +361,102 @@ func (f Flags) Apply() {
 
 		case HEIGHT, HEIGHT_SHORT:
 			skip = true
-			os.Setenv(envkeys.Height, getFlagArgument(args, ix))
+			os.Setenv(envkeys.Height, getFlagArgument(flagList, ix))
 
 		case WIDTH, WIDTH_SHORT:
 			skip = true
-			os.Setenv(envkeys.Width, getFlagArgument(args, ix))
+			os.Setenv(envkeys.Width, getFlagArgument(flagList, ix))
 
 		case MINES, MINES_SHORT:
 			skip = true
-			os.Setenv(envkeys.Mines, getFlagArgument(args, ix))
+			os.Setenv(envkeys.Mines, getFlagArgument(flagList, ix))
+
+		case LIVES, LIVES_SHORT:
+			skip = true
+			os.Setenv(envkeys.Lives, getFlagArgument(flagList, ix))
+
+		case PLAYERS, PLAYERS_SHORT:
+			skip = true
+			os.Setenv(envkeys.Players, getFlagArgument(flagList, ix))
+
+		case TIME_LIMIT, TIME_LIMIT_SHORT:
+			skip = true
+			os.Setenv(envkeys.TimeLimit, getFlagArgument(flagList, ix))
+
+		case TIME_BONUS, TIME_BONUS_SHORT:
+			skip = true
+			os.Setenv(envkeys.TimeBonus, getFlagArgument(flagList, ix))
 
 		case ASCII, ASCII_SHORT:
-			tilecontent.SetGlyph(tilecontent.Mine, "M")
-			tilecontent.SetGlyph(tilecontent.Flag, "F")
-			tilecontent.SetGlyph(tilecontent.WrongFlag, "W")
-			tilecontent.SetGlyph(tilecontent.Empty, " ")
-
-			tilecontent.SetGlyph(tilecontent.Zero, "x")
-			tilecontent.SetGlyph(tilecontent.One, "1")
-			tilecontent.SetGlyph(tilecontent.Two, "2")
-			tilecontent.SetGlyph(tilecontent.Three, "3")
-			tilecontent.SetGlyph(tilecontent.Four, "4")
-			tilecontent.SetGlyph(tilecontent.Five, "5")
-			tilecontent.SetGlyph(tilecontent.Six, "6")
-			tilecontent.SetGlyph(tilecontent.Seven, "7")
-			tilecontent.SetGlyph(tilecontent.Eight, "8")
+			for _, tileContent := range tilecontent.All {
+				tilecontent.SetGlyph(tileContent, tileContent.ASCII())
+			}
+
+		case SEED, SEED_SHORT:
+			skip = true
+			os.Setenv(envkeys.Seed, getFlagArgument(flagList, ix))
+
+		case HOST:
+			address := consts.DefaultHostAddress
+			if hasFlagArgument(flagList, ix) {
+				skip = true
+				address = getFlagArgument(flagList, ix)
+			}
+			os.Setenv(envkeys.RaceHost, address)
+
+		case JOIN:
+			skip = true
+			os.Setenv(envkeys.RaceJoin, getFlagArgument(flagList, ix))
+
+		case COOP_HOST:
+			address := consts.DefaultHostAddress
+			if hasFlagArgument(flagList, ix) {
+				skip = true
+				address = getFlagArgument(flagList, ix)
+			}
+			os.Setenv(envkeys.CoopHost, address)
+
+		case COOP_JOIN:
+			skip = true
+			os.Setenv(envkeys.CoopJoin, getFlagArgument(flagList, ix))
+
+		case SSH:
+			skip = true
+			os.Setenv(envkeys.ServeSSH, getFlagArgument(flagList, ix))
+
+		case HTTP:
+			skip = true
+			os.Setenv(envkeys.ServeHTTP, getFlagArgument(flagList, ix))
 
 		case FILL, FILL_SHORT:
 			styles.SetFill(true)
 
+		case STRICT, STRICT_SHORT:
+			os.Setenv(envkeys.WinCondition, string(winconditions.Strict))
+
+		case NO_FLAG, NO_FLAG_SHORT:
+			os.Setenv(envkeys.NoFlag, "true")
+
+		case HEADLESS:
+			os.Setenv(envkeys.Headless, "true")
+
+		case PRESET:
+			skip = true
+			preset, _ := presets.GetPreset(getFlagArgument(flagList, ix))
+			os.Setenv(envkeys.Width, strconv.FormatUint(uint64(preset.Width), 10))
+			os.Setenv(envkeys.Height, strconv.FormatUint(uint64(preset.Height), 10))
+			os.Setenv(envkeys.Mines, strconv.FormatUint(uint64(preset.Mines), 10))
+
+		case BENCH:
+			os.Setenv(envkeys.Bench, "true")
+
+		case GAMES:
+			skip = true
+			os.Setenv(envkeys.BenchGames, getFlagArgument(flagList, ix))
+
+		case CONFIG_COMMAND:
+			skip = true
+
 		case DEFAULT_CONFIG, DEFAULT_CONFIG_SHORT:
 			ResetConfig()
 		}
@@ -241,7 +464,8 @@ func (f Flags) Apply() {
 }
 
 func ApplyFromArgs() {
-	for _, arg := range os.Args[1:] {
+	args := os.Args[1:]
+	for ix, arg := range args {
 		switch arg {
 		case HELP:
 			fmt.Print(consts.HelpMessage)
@@ -256,6 +480,12 @@ func ApplyFromArgs() {
 
 		case DEFAULT_CONFIG, DEFAULT_CONFIG_SHORT:
 			ResetConfig()
+
+		// The bindings are checked before the rest of the config is validated so the conflicts are shown even if they make it invalid
+		case CONFIG_COMMAND:
+			if hasFlagArgument(args, ix) && getFlagArgument(args, ix) == CHECK_BINDINGS {
+				os.Setenv(envkeys.CheckBindings, "true")
+			}
 		}
 	}
 }
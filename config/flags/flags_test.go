@@ -148,6 +148,104 @@ func Test_Validate(t *testing.T) {
 				isValid: true,
 			},
 		},
+		{
+			args: []string{SEED, "seed"},
+			expected: Result{
+				errors:  []error{&MustBeInt64FlagError{SEED}},
+				isValid: false,
+			},
+		},
+		{
+			args: []string{SEED_SHORT, "-1234"},
+			expected: Result{
+				errors:  []error{},
+				isValid: true,
+			},
+		},
+		{
+			args: []string{JOIN},
+			expected: Result{
+				errors:  []error{&NoArgumentProvidedFlagError{JOIN}},
+				isValid: false,
+			},
+		},
+		{
+			args: []string{HOST, FILL},
+			expected: Result{
+				errors:  []error{},
+				isValid: true,
+			},
+		},
+		{
+			args: []string{HOST, "127.0.0.1:7777"},
+			expected: Result{
+				errors:  []error{},
+				isValid: true,
+			},
+		},
+		{
+			args: []string{COOP_JOIN},
+			expected: Result{
+				errors:  []error{&NoArgumentProvidedFlagError{COOP_JOIN}},
+				isValid: false,
+			},
+		},
+		{
+			args: []string{HEADLESS, WIDTH, "9"},
+			expected: Result{
+				errors:  []error{},
+				isValid: true,
+			},
+		},
+		{
+			args: []string{BENCH, GAMES, "100", PRESET, "expert"},
+			expected: Result{
+				errors:  []error{},
+				isValid: true,
+			},
+		},
+		{
+			args: []string{PRESET, "impossible"},
+			expected: Result{
+				errors:  []error{&InvalidPresetFlagError{"impossible"}},
+				isValid: false,
+			},
+		},
+		{
+			args: []string{SERVE},
+			expected: Result{
+				errors:  []error{&NoServerProvidedFlagError{SERVE}},
+				isValid: false,
+			},
+		},
+		{
+			args: []string{SERVE, HTTP, ":8080"},
+			expected: Result{
+				errors:  []error{},
+				isValid: true,
+			},
+		},
+		{
+			args: []string{SERVE, HTTP},
+			expected: Result{
+				errors:  []error{&NoArgumentProvidedFlagError{HTTP}},
+				isValid: false,
+			},
+		},
+		{
+			args: []string{SERVE, SSH, ":2222"},
+			expected: Result{
+				errors:  []error{},
+				isValid: true,
+			},
+		},
+		{
+			args: []string{COOP_HOST, "127.0.0.1:7777"},
+			expected: Result{
+				errors:  []error{},
+				isValid: true,
+			},
+		},
 		{
 			args: []string{},
 			expected: Result{
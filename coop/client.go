@@ -0,0 +1,158 @@
+package coop
+
+import (
+	"net"
+	"slices"
+	"sync"
+
+	tilecontent "sweep/shared/consts/tile-content"
+	jsonlines "sweep/shared/jsonlines"
+	types "sweep/shared/types"
+)
+
+// Client mirrors the field of the server
+// Actions are sent to the server and applied only once it pushes the changes back
+type Client struct {
+	mu       sync.RWMutex
+	conn     *jsonlines.Conn
+	id       int
+	game     Game
+	tiles    [][]tilecontent.TileContent
+	cursors  []Cursor
+	finished bool
+	won      bool
+	updates  chan struct{}
+}
+
+func Join(address string, name string) (*Client, error) {
+	netConn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, &ConnectionError{address, err}
+	}
+	conn := jsonlines.NewConn(netConn)
+
+	if err = conn.Send(message{Kind: joinMessage, Name: name}); err != nil {
+		conn.Close()
+		return nil, &ConnectionError{address, err}
+	}
+
+	var msg message
+	if err = conn.Receive(&msg); err != nil {
+		conn.Close()
+		return nil, &ConnectionError{address, err}
+	}
+	if msg.Kind != welcomeMessage || msg.Game == nil {
+		conn.Close()
+		return nil, &UnexpectedMessageError{welcomeMessage, msg.Kind}
+	}
+
+	c := &Client{
+		conn:    conn,
+		id:      msg.ID,
+		game:    *msg.Game,
+		updates: make(chan struct{}, 1),
+	}
+	c.tiles = make([][]tilecontent.TileContent, c.game.Height)
+	for y := range c.game.Height {
+		c.tiles[y] = make([]tilecontent.TileContent, c.game.Width)
+		for x := range c.game.Width {
+			c.tiles[y][x] = tilecontent.Empty
+		}
+	}
+	c.apply(msg)
+
+	go c.listen()
+
+	return c, nil
+}
+
+func (c *Client) apply(msg message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, update := range msg.Tiles {
+		x, y := update.Position.GetCoords()
+		if y < c.game.Height && x < c.game.Width {
+			c.tiles[y][x] = update.Content
+		}
+	}
+	if msg.Cursors != nil {
+		c.cursors = msg.Cursors
+	}
+	if msg.Tiles != nil {
+		c.finished = msg.Finished
+		c.won = msg.Won
+	}
+}
+
+func (c *Client) listen() {
+	jsonlines.Listen(c.conn, func(msg message) {
+		if msg.Kind != updateMessage {
+			return
+		}
+		c.apply(msg)
+		select {
+		case c.updates <- struct{}{}:
+		default:
+		}
+	})
+	close(c.updates)
+}
+
+// Receives a notification whenever the field or the cursors change
+// The channel is closed once the server is gone
+func (c *Client) Updates() <-chan struct{} {
+	return c.updates
+}
+
+func (c *Client) GetID() int {
+	return c.id
+}
+
+func (c *Client) GetGame() Game {
+	return c.game
+}
+
+func (c *Client) GetTile(position types.Position) tilecontent.TileContent {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	x, y := position.GetCoords()
+	if y >= c.game.Height || x >= c.game.Width {
+		return tilecontent.Empty
+	}
+	return c.tiles[y][x]
+}
+
+func (c *Client) GetCursors() []Cursor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.cursors)
+}
+
+func (c *Client) IsFinished() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.finished
+}
+
+func (c *Client) IsWon() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.won
+}
+
+func (c *Client) Open(position types.Position) error {
+	return c.conn.Send(message{Kind: actionMessage, Action: openAction, Position: &position})
+}
+
+func (c *Client) Flag(position types.Position) error {
+	return c.conn.Send(message{Kind: actionMessage, Action: flagAction, Position: &position})
+}
+
+func (c *Client) MoveCursor(position types.Position) error {
+	return c.conn.Send(message{Kind: cursorMessage, Position: &position})
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,83 @@
+package coop
+
+import (
+	"fmt"
+
+	tilecontent "sweep/shared/consts/tile-content"
+	types "sweep/shared/types"
+)
+
+type Game struct {
+	Seed   int64  `json:"seed"`
+	Width  uint16 `json:"width"`
+	Height uint16 `json:"height"`
+	Mines  uint16 `json:"mines"`
+}
+
+type Cursor struct {
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Position types.Position `json:"position"`
+}
+
+// A tile that has changed since the last update
+type TileUpdate struct {
+	Position types.Position          `json:"position"`
+	Content  tilecontent.TileContent `json:"content"`
+}
+
+type actionKind string
+
+const (
+	openAction actionKind = "open"
+	flagAction actionKind = "flag"
+)
+
+type messageKind string
+
+const (
+	joinMessage    messageKind = "join"
+	welcomeMessage messageKind = "welcome"
+	actionMessage  messageKind = "action"
+	cursorMessage  messageKind = "cursor"
+	updateMessage  messageKind = "update"
+)
+
+type message struct {
+	Kind     messageKind     `json:"kind"`
+	ID       int             `json:"id,omitempty"`
+	Name     string          `json:"name,omitempty"`
+	Game     *Game           `json:"game,omitempty"`
+	Action   actionKind      `json:"action,omitempty"`
+	Position *types.Position `json:"position,omitempty"`
+	Tiles    []TileUpdate    `json:"tiles,omitempty"`
+	Cursors  []Cursor        `json:"cursors,omitempty"`
+	Finished bool            `json:"finished,omitempty"`
+	Won      bool            `json:"won,omitempty"`
+}
+
+type ConnectionError struct {
+	address string
+	err     error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("could not connect to \"%v\": %v", e.address, e.err)
+}
+
+func (e *ConnectionError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type UnexpectedMessageError struct {
+	expected messageKind
+	actual   messageKind
+}
+
+func (e *UnexpectedMessageError) Error() string {
+	return fmt.Sprintf("expected \"%v\" message from the server, received \"%v\"", e.expected, e.actual)
+}
+
+func (e *UnexpectedMessageError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
@@ -0,0 +1,157 @@
+package coop
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	tilecontent "sweep/shared/consts/tile-content"
+	types "sweep/shared/types"
+)
+
+const timeout = 2 * time.Second
+
+// Waits until the condition holds checking it after every update of the client
+// The condition is also polled since it may depend on other clients
+func waitFor(t *testing.T, client *Client, condition func() bool) {
+	t.Helper()
+	deadline := time.After(timeout)
+	for !condition() {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case _, ok := <-client.Updates():
+			if !ok {
+				t.Fatalf("[Assertion failed] client #%v was disconnected", client.GetID())
+			}
+		case <-deadline:
+			t.Fatalf("[Assertion failed] client #%v did not receive expected update in time", client.GetID())
+		}
+	}
+}
+
+func TestCoop(t *testing.T) {
+	game := Game{Seed: 42, Width: 9, Height: 9, Mines: 10}
+	server, err := Listen("127.0.0.1:0", game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	first, err := Join(server.GetAddress(), "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	second, err := Join(server.GetAddress(), "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	if first.GetID() == second.GetID() {
+		t.Errorf("[Assertion failed] IDs are not unique\nfirst: %v, second: %v", first.GetID(), second.GetID())
+	}
+	if second.GetGame() != game {
+		t.Errorf("[Assertion failed] game\nExpected: %v\nActual: %v", game, second.GetGame())
+	}
+
+	waitFor(t, first, func() bool {
+		return len(first.GetCursors()) == 2
+	})
+
+	center := types.Position{X: 4, Y: 4}
+	if err := first.Open(center); err != nil {
+		t.Fatal(err)
+	}
+	for _, client := range []*Client{first, second} {
+		waitFor(t, client, func() bool {
+			return client.GetTile(center) != tilecontent.Empty
+		})
+	}
+
+	for y := range game.Height {
+		for x := range game.Width {
+			position := types.Position{X: x, Y: y}
+			if first.GetTile(position) != second.GetTile(position) {
+				t.Errorf("[Assertion failed] tile %v differs between clients\nfirst: %v, second: %v", position, first.GetTile(position), second.GetTile(position))
+			}
+		}
+	}
+
+	corner := types.Position{X: 8, Y: 8}
+	if err := second.MoveCursor(corner); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, first, func() bool {
+		for _, cursor := range first.GetCursors() {
+			if cursor.ID == second.GetID() {
+				return cursor.Position == corner && cursor.Name == "second"
+			}
+		}
+		return false
+	})
+
+	second.Close()
+	waitFor(t, first, func() bool {
+		return len(first.GetCursors()) == 1
+	})
+}
+
+func TestCoopConcurrentActions(t *testing.T) {
+	game := Game{Seed: 7, Width: 16, Height: 16, Mines: 40}
+	server, err := Listen("127.0.0.1:0", game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	clients := make([]*Client, 3)
+	for ix := range clients {
+		clients[ix], err = Join(server.GetAddress(), "player")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer clients[ix].Close()
+	}
+
+	clients[0].Open(types.Position{X: 8, Y: 8})
+	waitFor(t, clients[0], func() bool {
+		return clients[0].GetTile(types.Position{X: 8, Y: 8}) != tilecontent.Empty
+	})
+
+	var wg sync.WaitGroup
+	for ix, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := range game.Height {
+				position := types.Position{X: uint16(ix), Y: y}
+				client.Flag(position)
+				client.Open(types.Position{X: game.Width - 1 - uint16(ix), Y: y})
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A late joiner receives the snapshot of the field which every other client has to match
+	late, err := Join(server.GetAddress(), "late")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer late.Close()
+
+	for _, client := range clients {
+		waitFor(t, client, func() bool {
+			for y := range game.Height {
+				for x := range game.Width {
+					position := types.Position{X: x, Y: y}
+					if client.GetTile(position) != late.GetTile(position) {
+						return false
+					}
+				}
+			}
+			return client.IsFinished() == late.IsFinished()
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package coop
+
+import (
+	"net"
+	"slices"
+	"sync"
+
+	gameengine "sweep/game-engine"
+	tilecontent "sweep/shared/consts/tile-content"
+	tiles "sweep/shared/consts/tiles"
+	jsonlines "sweep/shared/jsonlines"
+	types "sweep/shared/types"
+)
+
+// Server owns the only game engine, every action of every player goes through it
+// and the changed tiles are pushed to everyone in the order the actions were made
+type Server struct {
+	mu       sync.Mutex
+	listener net.Listener
+	engine   *gameengine.SyncGameEngine
+	game     Game
+	clients  map[int]*jsonlines.Conn
+	cursors  []Cursor
+	nextID   int
+	minesSet bool
+}
+
+func Listen(address string, game Game) (*Server, error) {
+	engine := &gameengine.SyncGameEngine{}
+	if err := engine.SetFieldSize(game.Width, game.Height); err != nil {
+		return nil, err
+	}
+	if err := engine.SetMineCount(game.Mines); err != nil {
+		return nil, err
+	}
+	if game.Seed != 0 {
+		engine.SetSeed(game.Seed)
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, &ConnectionError{address, err}
+	}
+
+	s := &Server{
+		listener: listener,
+		engine:   engine,
+		game:     game,
+		clients:  map[int]*jsonlines.Conn{},
+		nextID:   1,
+	}
+	go s.accept()
+
+	return s, nil
+}
+
+func (s *Server) GetAddress() string {
+	return s.listener.Addr().String()
+}
+
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serve(conn)
+	}
+}
+
+func (s *Server) serve(conn net.Conn) {
+	client := jsonlines.NewConn(conn)
+	defer client.Close()
+
+	var msg message
+	if err := client.Receive(&msg); err != nil || msg.Kind != joinMessage {
+		return
+	}
+
+	s.mu.Lock()
+	id := s.nextID
+	s.nextID++
+	s.cursors = append(s.cursors, Cursor{
+		ID:       id,
+		Name:     msg.Name,
+		Position: types.Position{X: s.game.Width / 2, Y: s.game.Height / 2},
+	})
+	err := client.Send(message{
+		Kind:     welcomeMessage,
+		ID:       id,
+		Game:     &s.game,
+		Tiles:    s.getSnapshot(),
+		Cursors:  s.cursors,
+		Finished: s.engine.IsFinished(),
+		Won:      s.engine.IsWon(),
+	})
+	if err == nil {
+		s.clients[id] = client
+		s.broadcast(message{Kind: updateMessage, Cursors: s.cursors})
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	jsonlines.Listen(client, func(msg message) {
+		if msg.Position == nil {
+			return
+		}
+		switch msg.Kind {
+		case actionMessage:
+			s.act(msg.Action, *msg.Position)
+		case cursorMessage:
+			s.moveCursor(id, *msg.Position)
+		}
+	})
+
+	s.mu.Lock()
+	delete(s.clients, id)
+	s.cursors = slices.DeleteFunc(s.cursors, func(cursor Cursor) bool {
+		return cursor.ID == id
+	})
+	s.broadcast(message{Kind: updateMessage, Cursors: s.cursors})
+	s.mu.Unlock()
+}
+
+// Has to be called with the lock held so updates are sent in order
+func (s *Server) broadcast(msg message) {
+	for _, client := range s.clients {
+		client.Send(msg)
+	}
+}
+
+func (s *Server) act(action actionKind, position types.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.engine.IsFinished() || s.engine.GetTile(position) == tiles.OutOfBounds {
+		return
+	}
+
+	var changed []types.Position
+	switch action {
+	case openAction:
+		if !s.minesSet {
+			s.engine.SetMines(position)
+			s.minesSet = true
+		}
+		changed = s.engine.Reveal(position)
+	case flagAction:
+		if !s.minesSet {
+			return
+		}
+		s.engine.FlagToggleTile(position)
+		changed = []types.Position{position}
+	}
+
+	if len(changed) == 0 {
+		return
+	}
+
+	var updates []TileUpdate
+	if s.engine.IsFinished() {
+		updates = s.getFinalTiles()
+	} else {
+		updates = make([]TileUpdate, len(changed))
+		for ix, position := range changed {
+			updates[ix] = TileUpdate{position, s.engine.GetTileContent(position)}
+		}
+	}
+
+	s.broadcast(message{
+		Kind:     updateMessage,
+		Tiles:    updates,
+		Finished: s.engine.IsFinished(),
+		Won:      s.engine.IsWon(),
+	})
+}
+
+func (s *Server) moveCursor(id int, position types.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ix := range s.cursors {
+		if s.cursors[ix].ID == id {
+			s.cursors[ix].Position = position
+		}
+	}
+	s.broadcast(message{Kind: updateMessage, Cursors: s.cursors})
+}
+
+// Returns every tile that is not closed
+func (s *Server) getSnapshot() []TileUpdate {
+	if s.engine.IsFinished() {
+		return s.getFinalTiles()
+	}
+	snapshot := []TileUpdate{}
+	for y := range s.game.Height {
+		for x := range s.game.Width {
+			position := types.Position{X: x, Y: y}
+			if content := s.engine.GetTileContent(position); content != tilecontent.Empty {
+				snapshot = append(snapshot, TileUpdate{position, content})
+			}
+		}
+	}
+	return snapshot
+}
+
+// Reveals mines and wrong flags once the game is over
+// Remaining mines are flagged on a win
+func (s *Server) getFinalTiles() []TileUpdate {
+	isWon := s.engine.IsWon()
+	final := []TileUpdate{}
+	for y := range s.game.Height {
+		for x := range s.game.Width {
+			position := types.Position{X: x, Y: y}
+			content := s.engine.GetTileContent(position)
+			switch s.engine.GetTile(position) {
+			case tiles.ClosedMine:
+				content = tilecontent.Mine
+				if isWon {
+					content = tilecontent.Flag
+				}
+			case tiles.FlaggedSafe:
+				content = tilecontent.WrongFlag
+			}
+			final = append(final, TileUpdate{position, content})
+		}
+	}
+	return final
+}
+
+func (s *Server) Close() error {
+	s.mu.Lock()
+	for _, client := range s.clients {
+		client.Close()
+	}
+	s.mu.Unlock()
+	return s.listener.Close()
+}
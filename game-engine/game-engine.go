@@ -7,7 +7,9 @@ import (
 	"sync"
 	"sync/atomic"
 
+	tilecontent "sweep/shared/consts/tile-content"
 	tiles "sweep/shared/consts/tiles"
+	winconditions "sweep/shared/consts/win-conditions"
 	types "sweep/shared/types"
 )
 
@@ -15,6 +17,11 @@ var _ types.IGameEngine = (*GameEngine)(nil)
 
 type GameEngine struct {
 	isFinished       bool
+	isWon            bool
+	lives            uint16
+	explosions       []types.Position
+	winCondition     winconditions.WinCondition
+	random           *rand.Rand
 	mines            uint16
 	width            uint16
 	height           uint16
@@ -60,17 +67,35 @@ func (g *GameEngine) SetMineCount(count uint16) error {
 	return nil
 }
 
-func (g *GameEngine) CountNeighbouringMines(position types.Position) byte {
-	x, y := position.GetCoords()
-	if x > g.width {
-		return 0
-	}
-	if y > g.height {
-		return 0
+// Sets the amount of mines that can be hit before the game is lost
+func (g *GameEngine) SetLives(count uint16) error {
+	if count == 0 {
+		return &FieldParameterCannotBe0Error{"lives"}
 	}
-	var counter uint32 = 0
+	g.lives = count
 
-	neighbours := []types.Position{
+	return nil
+}
+
+// Classic win condition is used when none is set
+func (g *GameEngine) SetWinCondition(winCondition winconditions.WinCondition) {
+	g.winCondition = winCondition
+}
+
+func (g *GameEngine) GetLives() uint16 {
+	return g.lives
+}
+
+// Returns positions of all the mines that were hit in order
+func (g *GameEngine) GetExplosions() []types.Position {
+	return g.explosions
+}
+
+// Neighbours out of the field are returned as well
+// so they have to be filtered out with GetTile if needed
+func getNeighbours(position types.Position) []types.Position {
+	x, y := position.GetCoords()
+	return []types.Position{
 		{X: x - 1, Y: y - 1},
 		{X: x - 1, Y: y},
 		{X: x - 1, Y: y + 1},
@@ -80,6 +105,19 @@ func (g *GameEngine) CountNeighbouringMines(position types.Position) byte {
 		{X: x + 1, Y: y},
 		{X: x + 1, Y: y + 1},
 	}
+}
+
+func (g *GameEngine) CountNeighbouringMines(position types.Position) byte {
+	x, y := position.GetCoords()
+	if x > g.width {
+		return 0
+	}
+	if y > g.height {
+		return 0
+	}
+	var counter uint32 = 0
+
+	neighbours := getNeighbours(position)
 
 	var wg sync.WaitGroup
 	wg.Add(len(neighbours))
@@ -100,10 +138,8 @@ func (g *GameEngine) CountNeighbouringMines(position types.Position) byte {
 
 func (g *GameEngine) OpenTile(position types.Position) {
 	switch g.GetTile(position) {
-	case tiles.ClosedMine, tiles.FlaggedMine, tiles.OpenMine:
-		g.openCount++
-		g.isFinished = true
-		g.setTile(position, tiles.OpenMine)
+	case tiles.ClosedMine, tiles.FlaggedMine:
+		g.explodeMine(position)
 		return
 	case tiles.FlaggedSafe:
 		g.openCount++
@@ -115,6 +151,102 @@ func (g *GameEngine) OpenTile(position types.Position) {
 	g.checkWinCondition()
 }
 
+func (g *GameEngine) explodeMine(position types.Position) {
+	if g.GetTile(position) == tiles.FlaggedMine {
+		g.flaggedMineCount--
+		g.flaggedCount--
+	}
+	g.setTile(position, tiles.OpenMine)
+	g.explosions = append(g.explosions, position)
+
+	if g.lives > 1 {
+		g.lives--
+		g.checkWinCondition()
+		return
+	}
+	g.lives = 0
+	g.isFinished = true
+}
+
+// Opens the tile the way a player would
+// Tiles without mines around open their neighbours as well
+// and opening an open tile opens its closed neighbours if the amount of flags around matches its number
+// Returns positions of every tile that was opened
+func (g *GameEngine) Reveal(position types.Position) []types.Position {
+	switch g.GetTile(position) {
+	case tiles.ClosedSafe, tiles.ClosedMine:
+		return g.openArea(position)
+	case tiles.OpenSafe:
+		return g.chord(position)
+	}
+	return nil
+}
+
+func (g *GameEngine) openArea(position types.Position) []types.Position {
+	opened := []types.Position{}
+	stack := []types.Position{position}
+
+	for len(stack) > 0 && !g.isFinished {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		switch g.GetTile(current) {
+		case tiles.ClosedSafe, tiles.ClosedMine:
+		default:
+			continue
+		}
+
+		g.OpenTile(current)
+		opened = append(opened, current)
+
+		if g.GetTile(current) == tiles.OpenSafe && g.CountNeighbouringMines(current) == 0 {
+			stack = append(stack, getNeighbours(current)...)
+		}
+	}
+
+	return opened
+}
+
+func (g *GameEngine) chord(position types.Position) []types.Position {
+	neighbours := getNeighbours(position)
+
+	var flagCount byte
+	for _, neighbour := range neighbours {
+		// Exploded mines are as good as flagged ones
+		switch g.GetTile(neighbour) {
+		case tiles.FlaggedMine, tiles.FlaggedSafe, tiles.OpenMine:
+			flagCount++
+		}
+	}
+	if flagCount != g.CountNeighbouringMines(position) {
+		return nil
+	}
+
+	opened := []types.Position{}
+	for _, neighbour := range neighbours {
+		if g.isFinished {
+			break
+		}
+		opened = append(opened, g.openArea(neighbour)...)
+	}
+	return opened
+}
+
+// Returns the content of the tile as the player sees it
+func (g *GameEngine) GetTileContent(position types.Position) tilecontent.TileContent {
+	switch g.GetTile(position) {
+	case tiles.OpenSafe:
+		tileContent, _ := tilecontent.FromNumber(g.CountNeighbouringMines(position))
+		return tileContent
+	case tiles.OpenMine:
+		return tilecontent.Mine
+	case tiles.FlaggedMine, tiles.FlaggedSafe:
+		return tilecontent.Flag
+	default:
+		return tilecontent.Empty
+	}
+}
+
 func (g *GameEngine) GetTile(position types.Position) types.Tile {
 	x, y := position.GetCoords()
 	if x >= g.width || y >= g.height {
@@ -132,8 +264,10 @@ func (g *GameEngine) setTile(position types.Position, tile types.Tile) {
 	g.field[y][x] = tile
 }
 
+// Mines that were hit count as flagged ones
 func (g *GameEngine) areAllMinesFlagged() bool {
-	return g.flaggedCount == g.mines && g.mines == g.flaggedMineCount
+	exploded := uint16(len(g.explosions))
+	return g.flaggedCount+exploded == g.mines && g.mines == g.flaggedMineCount+exploded
 }
 
 func (g *GameEngine) areAllSafeTilesOpen() bool {
@@ -146,7 +280,13 @@ func (g *GameEngine) checkWinCondition() {
 	if g.isFinished {
 		return
 	}
-	g.isFinished = (g.areAllMinesFlagged() && g.areAllSafeTilesOpen())
+	switch g.winCondition {
+	case winconditions.Strict:
+		g.isWon = g.areAllMinesFlagged() && g.areAllSafeTilesOpen()
+	default:
+		g.isWon = g.areAllSafeTilesOpen()
+	}
+	g.isFinished = g.isWon
 }
 
 // Second return value is whether the tile is a Mine
@@ -171,6 +311,19 @@ func (g *GameEngine) FlagToggleTile(position types.Position) {
 	g.checkWinCondition()
 }
 
+// Makes mine generation reproducible, the same seed gives the same field
+// as long as the field size, the mine count and the safe tile are the same
+func (g *GameEngine) SetSeed(seed int64) {
+	g.random = rand.New(rand.NewSource(seed))
+}
+
+func (g *GameEngine) intn(n int) int {
+	if g.random == nil {
+		return rand.Intn(n)
+	}
+	return g.random.Intn(n)
+}
+
 // gameEngine.SetMines() sets the Mines on the field
 // Argument safeTile where no Mine can be generated
 // To removed a safeTile simply set it out of bounds (less then 0 or more then fieldSize)
@@ -182,7 +335,7 @@ func (g *GameEngine) SetMines(safeTile types.Position) {
 	var wg sync.WaitGroup
 
 	for len(MinePositions) < int(g.mines) {
-		x, y := uint16(rand.Intn(int(maxX+minValue))), uint16(rand.Intn(int(maxY+minValue)))
+		x, y := uint16(g.intn(int(maxX+minValue))), uint16(g.intn(int(maxY+minValue)))
 		currentPosition := types.Position{X: x, Y: y}
 		if currentPosition != safeTile && !slices.Contains(MinePositions, currentPosition) {
 			MinePositions = append(MinePositions, currentPosition)
@@ -203,6 +356,20 @@ func (g *GameEngine) IsFinished() bool {
 	return g.isFinished
 }
 
+func (g *GameEngine) IsWon() bool {
+	return g.isWon
+}
+
+// Finishes the game as lost regardless of the field state
+func (g *GameEngine) Forfeit() {
+	g.isFinished = true
+	g.isWon = false
+}
+
+func (g *GameEngine) GetOpenCount() uint16 {
+	return g.openCount
+}
+
 func (g *GameEngine) SetFieldSize(width uint16, height uint16) error {
 	if width == 0 {
 		return &FieldParameterCannotBe0Error{"field width"}
@@ -6,7 +6,9 @@ import (
 	"sync"
 	"testing"
 
+	tilecontent "sweep/shared/consts/tile-content"
 	tiles "sweep/shared/consts/tiles"
+	winconditions "sweep/shared/consts/win-conditions"
 	types "sweep/shared/types"
 )
 
@@ -463,6 +465,47 @@ func TestWinCondition(t *testing.T) {
 		{
 			isFinished: false,
 			prepare: func(g *GameEngine) {
+				g.winCondition = winconditions.Strict
+				g.field = [][]types.Tile{
+					{
+						tiles.ClosedSafe,
+						tiles.ClosedSafe,
+						tiles.ClosedSafe,
+					},
+
+					{
+						tiles.ClosedSafe,
+						tiles.ClosedMine,
+						tiles.ClosedSafe,
+					},
+
+					{
+						tiles.ClosedSafe,
+						tiles.ClosedSafe,
+						tiles.ClosedSafe,
+					},
+				}
+				g.mines = 1
+				g.width = 3
+				g.height = 3
+
+				g.OpenTile(types.Position{X: 0, Y: 0})
+				g.OpenTile(types.Position{X: 0, Y: 1})
+				g.OpenTile(types.Position{X: 0, Y: 2})
+
+				g.OpenTile(types.Position{X: 1, Y: 0})
+				g.OpenTile(types.Position{X: 1, Y: 2})
+
+				g.OpenTile(types.Position{X: 2, Y: 0})
+				g.OpenTile(types.Position{X: 2, Y: 1})
+				g.OpenTile(types.Position{X: 2, Y: 2})
+			},
+		},
+
+		{
+			isFinished: true,
+			prepare: func(g *GameEngine) {
+				g.winCondition = winconditions.Classic
 				g.field = [][]types.Tile{
 					{
 						tiles.ClosedSafe,
@@ -512,3 +555,262 @@ func TestWinCondition(t *testing.T) {
 		}
 	}
 }
+
+func TestLives(t *testing.T) {
+	type TestCase struct {
+		lives      uint16
+		isFinished bool
+		isWon      bool
+		livesLeft  uint16
+		explosions []types.Position
+		prepare    func(*GameEngine)
+	}
+
+	createField := func(g *GameEngine) {
+		g.field = [][]types.Tile{
+			{
+				tiles.ClosedMine, tiles.ClosedSafe,
+			},
+			{
+				tiles.ClosedSafe, tiles.ClosedMine,
+			},
+		}
+		g.width = 2
+		g.height = 2
+		g.mines = 2
+	}
+
+	testCases := []TestCase{
+		{
+			lives:      1,
+			isFinished: true,
+			isWon:      false,
+			livesLeft:  0,
+			explosions: []types.Position{{X: 0, Y: 0}},
+			prepare: func(g *GameEngine) {
+				g.OpenTile(types.Position{X: 0, Y: 0})
+			},
+		},
+		{
+			lives:      2,
+			isFinished: false,
+			isWon:      false,
+			livesLeft:  1,
+			explosions: []types.Position{{X: 0, Y: 0}},
+			prepare: func(g *GameEngine) {
+				g.OpenTile(types.Position{X: 0, Y: 0})
+			},
+		},
+		{
+			lives:      2,
+			isFinished: true,
+			isWon:      false,
+			livesLeft:  0,
+			explosions: []types.Position{{X: 0, Y: 0}, {X: 1, Y: 1}},
+			prepare: func(g *GameEngine) {
+				g.OpenTile(types.Position{X: 0, Y: 0})
+				g.OpenTile(types.Position{X: 1, Y: 1})
+			},
+		},
+		{
+			lives:      3,
+			isFinished: true,
+			isWon:      true,
+			livesLeft:  2,
+			explosions: []types.Position{{X: 0, Y: 0}},
+			prepare: func(g *GameEngine) {
+				g.OpenTile(types.Position{X: 0, Y: 0})
+				g.OpenTile(types.Position{X: 0, Y: 0})
+				g.OpenTile(types.Position{X: 1, Y: 0})
+				g.OpenTile(types.Position{X: 0, Y: 1})
+				g.FlagToggleTile(types.Position{X: 1, Y: 1})
+			},
+		},
+	}
+
+	for n, testCase := range testCases {
+		g := new(GameEngine)
+		createField(g)
+		if err := g.SetLives(testCase.lives); err != nil {
+			t.Fatal(err)
+		}
+		testCase.prepare(g)
+
+		if g.IsFinished() != testCase.isFinished {
+			t.Errorf("[Assertion failed] #%v g.IsFinished()\nExpected: %v\nActual: %v", n+1, testCase.isFinished, g.IsFinished())
+		}
+		if g.IsWon() != testCase.isWon {
+			t.Errorf("[Assertion failed] #%v g.IsWon()\nExpected: %v\nActual: %v", n+1, testCase.isWon, g.IsWon())
+		}
+		if g.GetLives() != testCase.livesLeft {
+			t.Errorf("[Assertion failed] #%v g.GetLives()\nExpected: %v\nActual: %v", n+1, testCase.livesLeft, g.GetLives())
+		}
+		if fmt.Sprint(g.GetExplosions()) != fmt.Sprint(testCase.explosions) {
+			t.Errorf("[Assertion failed] #%v g.GetExplosions()\nExpected: %v\nActual: %v", n+1, testCase.explosions, g.GetExplosions())
+		}
+	}
+}
+
+func TestSetLives(t *testing.T) {
+	g := GameEngine{}
+	expected := &FieldParameterCannotBe0Error{"lives"}
+	if err := g.SetLives(0); !errors.Is(err, expected) {
+		t.Errorf("[Assertion failed]\nExpected error: %v\nActual error: %v", expected, err)
+	}
+}
+
+func TestForfeit(t *testing.T) {
+	g := GameEngine{}
+	g.SetFieldSize(3, 3)
+	g.SetMineCount(1)
+	g.SetMines(types.Position{X: 1, Y: 1})
+	g.OpenTile(types.Position{X: 1, Y: 1})
+
+	g.Forfeit()
+
+	if !g.IsFinished() {
+		t.Errorf("[Assertion failed] forfeited game should be finished")
+	}
+	if g.IsWon() {
+		t.Errorf("[Assertion failed] forfeited game should not be won")
+	}
+	if g.GetOpenCount() != 1 {
+		t.Errorf("[Assertion failed] %v != %v\ngameEngine.GetOpenCount() != opened tiles", g.GetOpenCount(), 1)
+	}
+}
+
+func TestSetSeed(t *testing.T) {
+	const seed int64 = 1234
+	safePosition := types.Position{X: 5, Y: 5}
+
+	createField := func() [][]types.Tile {
+		g := GameEngine{}
+		g.SetFieldSize(10, 10)
+		g.SetMineCount(30)
+		g.SetSeed(seed)
+		g.SetMines(safePosition)
+		return g.GetField()
+	}
+
+	expected := createField()
+	actual := createField()
+
+	if fmt.Sprint(expected) != fmt.Sprint(actual) {
+		t.Errorf("[Assertion failed] fields generated with the same seed differ\nExpected: %v\nActual: %v", expected, actual)
+	}
+}
+
+func TestReveal(t *testing.T) {
+	type TestCase struct {
+		position types.Position
+		opened   int
+		prepare  func(*GameEngine)
+	}
+
+	createField := func(g *GameEngine) {
+		// Mine is in the top right corner
+		g.field = [][]types.Tile{
+			{tiles.ClosedSafe, tiles.ClosedSafe, tiles.ClosedSafe, tiles.ClosedSafe},
+			{tiles.ClosedSafe, tiles.ClosedSafe, tiles.ClosedSafe, tiles.ClosedSafe},
+			{tiles.ClosedSafe, tiles.ClosedSafe, tiles.ClosedSafe, tiles.ClosedSafe},
+			{tiles.ClosedSafe, tiles.ClosedSafe, tiles.ClosedSafe, tiles.ClosedMine},
+		}
+		g.width = 4
+		g.height = 4
+		g.mines = 1
+	}
+
+	testCases := []TestCase{
+		{
+			position: types.Position{X: 0, Y: 0},
+			opened:   15,
+			prepare:  func(g *GameEngine) {},
+		},
+		{
+			position: types.Position{X: 2, Y: 2},
+			opened:   1,
+			prepare:  func(g *GameEngine) {},
+		},
+		{
+			position: types.Position{X: 2, Y: 3},
+			opened:   0,
+			prepare: func(g *GameEngine) {
+				g.Reveal(types.Position{X: 2, Y: 3})
+			},
+		},
+		{
+			position: types.Position{X: 2, Y: 2},
+			opened:   14,
+			prepare: func(g *GameEngine) {
+				g.FlagToggleTile(types.Position{X: 3, Y: 3})
+				g.Reveal(types.Position{X: 2, Y: 2})
+			},
+		},
+		{
+			position: types.Position{X: 2, Y: 2},
+			opened:   0,
+			prepare: func(g *GameEngine) {
+				g.Reveal(types.Position{X: 2, Y: 2})
+			},
+		},
+		{
+			position: types.Position{X: 3, Y: 3},
+			opened:   1,
+			prepare:  func(g *GameEngine) {},
+		},
+	}
+
+	for n, testCase := range testCases {
+		g := new(GameEngine)
+		createField(g)
+		testCase.prepare(g)
+
+		opened := g.Reveal(testCase.position)
+		if len(opened) != testCase.opened {
+			t.Errorf("[Assertion failed] #%v opened tile count\nExpected: %v\nActual: %v (%v)", n+1, testCase.opened, len(opened), opened)
+		}
+	}
+
+	g := new(GameEngine)
+	createField(g)
+	g.Reveal(types.Position{X: 0, Y: 0})
+	if !g.IsFinished() || !g.IsWon() {
+		t.Errorf("[Assertion failed] opening the whole field should have won the game")
+	}
+	if content := g.GetTileContent(types.Position{X: 2, Y: 2}); content != tilecontent.One {
+		t.Errorf("[Assertion failed] %v != %v\ntile content next to the mine", content, tilecontent.One)
+	}
+	if content := g.GetTileContent(types.Position{X: 3, Y: 3}); content != tilecontent.Empty {
+		t.Errorf("[Assertion failed] %v != %v\nclosed mine content", content, tilecontent.Empty)
+	}
+}
+
+func TestSyncGameEngine(t *testing.T) {
+	g := SyncGameEngine{}
+	g.SetFieldSize(30, 16)
+	g.SetMineCount(99)
+	g.SetMines(types.Position{X: 0, Y: 0})
+
+	var wg sync.WaitGroup
+	for y := range uint16(16) {
+		wg.Go(func() {
+			for x := range uint16(30) {
+				position := types.Position{X: x, Y: y}
+				if g.GetTile(position) == tiles.ClosedSafe {
+					g.Reveal(position)
+				} else {
+					g.FlagToggleTile(position)
+				}
+				g.GetTileContent(position)
+			}
+		})
+	}
+	wg.Wait()
+
+	if g.GetOpenCount() != 30*16-99 {
+		t.Errorf("[Assertion failed] %v != %v\nevery safe tile should be open", g.GetOpenCount(), 30*16-99)
+	}
+	if !g.IsWon() {
+		t.Errorf("[Assertion failed] opening every safe tile should have won the game")
+	}
+}
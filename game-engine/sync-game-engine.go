@@ -0,0 +1,150 @@
+package gameengine
+
+import (
+	"slices"
+	"sync"
+
+	tilecontent "sweep/shared/consts/tile-content"
+	winconditions "sweep/shared/consts/win-conditions"
+	types "sweep/shared/types"
+)
+
+var _ types.IGameEngine = (*SyncGameEngine)(nil)
+
+// SyncGameEngine is a GameEngine safe for concurrent use
+// Every call is serialized so the field is never seen half updated
+type SyncGameEngine struct {
+	mu     sync.RWMutex
+	engine GameEngine
+}
+
+func (s *SyncGameEngine) FlagToggleTile(position types.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.engine.FlagToggleTile(position)
+}
+
+func (s *SyncGameEngine) OpenTile(position types.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.engine.OpenTile(position)
+}
+
+func (s *SyncGameEngine) Reveal(position types.Position) []types.Position {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.engine.Reveal(position)
+}
+
+func (s *SyncGameEngine) GetTile(position types.Position) types.Tile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.GetTile(position)
+}
+
+func (s *SyncGameEngine) GetTileContent(position types.Position) tilecontent.TileContent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.GetTileContent(position)
+}
+
+func (s *SyncGameEngine) IsFinished() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.IsFinished()
+}
+
+func (s *SyncGameEngine) IsWon() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.IsWon()
+}
+
+func (s *SyncGameEngine) Forfeit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.engine.Forfeit()
+}
+
+func (s *SyncGameEngine) GetOpenCount() uint16 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.GetOpenCount()
+}
+
+// Returns a copy of the field so it can be read while the game goes on
+func (s *SyncGameEngine) GetField() [][]types.Tile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	field := make([][]types.Tile, len(s.engine.field))
+	for y := range s.engine.field {
+		field[y] = slices.Clone(s.engine.field[y])
+	}
+	return field
+}
+
+func (s *SyncGameEngine) SetFieldSize(width uint16, height uint16) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.engine.SetFieldSize(width, height)
+}
+
+func (s *SyncGameEngine) SetMineCount(count uint16) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.engine.SetMineCount(count)
+}
+
+func (s *SyncGameEngine) SetMines(safeTile types.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.engine.SetMines(safeTile)
+}
+
+func (s *SyncGameEngine) SetSeed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.engine.SetSeed(seed)
+}
+
+func (s *SyncGameEngine) CountNeighbouringMines(position types.Position) byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.CountNeighbouringMines(position)
+}
+
+func (s *SyncGameEngine) SetLives(count uint16) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.engine.SetLives(count)
+}
+
+func (s *SyncGameEngine) SetWinCondition(winCondition winconditions.WinCondition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.engine.SetWinCondition(winCondition)
+}
+
+func (s *SyncGameEngine) GetLives() uint16 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.GetLives()
+}
+
+func (s *SyncGameEngine) GetExplosions() []types.Position {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.engine.GetExplosions())
+}
+
+func (s *SyncGameEngine) GetWidth() uint16 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.GetWidth()
+}
+
+func (s *SyncGameEngine) GetHeight() uint16 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine.GetHeight()
+}
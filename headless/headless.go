@@ -0,0 +1,355 @@
+package headless
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	gameengine "sweep/game-engine"
+	tilecontent "sweep/shared/consts/tile-content"
+	tiles "sweep/shared/consts/tiles"
+	winconditions "sweep/shared/consts/win-conditions"
+	types "sweep/shared/types"
+)
+
+type CommandKind string
+
+const (
+	New   CommandKind = "new"
+	Open  CommandKind = "open"
+	Flag  CommandKind = "flag"
+	Chord CommandKind = "chord"
+	State CommandKind = "state"
+)
+
+type Command struct {
+	Command CommandKind `json:"command"`
+	// Field parameters for the new command, parameters of the previous game are used when omitted
+	// An omitted seed is random unless one is set for the session
+	Width  uint16 `json:"width,omitempty"`
+	Height uint16 `json:"height,omitempty"`
+	Mines  uint16 `json:"mines,omitempty"`
+	Seed   int64  `json:"seed,omitempty"`
+	// Position for the open, flag and chord commands
+	X uint16 `json:"x"`
+	Y uint16 `json:"y"`
+}
+
+type Outcome string
+
+const (
+	Playing Outcome = "playing"
+	Won     Outcome = "won"
+	Lost    Outcome = "lost"
+)
+
+// Characters of the board
+// Mines and wrong flags are shown only once the game is over
+const (
+	closedChar    = '#'
+	flagChar      = 'F'
+	mineChar      = '*'
+	wrongFlagChar = 'X'
+)
+
+type Response struct {
+	OK      bool    `json:"ok"`
+	Error   string  `json:"error,omitempty"`
+	Outcome Outcome `json:"outcome,omitempty"`
+	Width   uint16  `json:"width,omitempty"`
+	Height  uint16  `json:"height,omitempty"`
+	Mines   uint16  `json:"mines,omitempty"`
+	Flags   uint16  `json:"flags"`
+	Open    uint16  `json:"open"`
+	Lives   uint16  `json:"lives"`
+	// Rows of the board, the tile at column x of row y is board[y][x]
+	Board []string `json:"board,omitempty"`
+}
+
+// Defaults for the games of the session
+type Game struct {
+	Width        uint16
+	Height       uint16
+	Mines        uint16
+	Seed         int64
+	Lives        uint16
+	WinCondition winconditions.WinCondition
+	NoFlag       bool
+}
+
+type NoGameError struct{}
+
+func (e *NoGameError) Error() string {
+	return "no game was started, send the \"new\" command first"
+}
+
+func (e *NoGameError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type GameIsOverError struct{}
+
+func (e *GameIsOverError) Error() string {
+	return "the game is over, send the \"new\" command to start another one"
+}
+
+func (e *GameIsOverError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidPositionError struct {
+	command  CommandKind
+	position types.Position
+}
+
+func (e *InvalidPositionError) Error() string {
+	return fmt.Sprintf("can not %v the tile at x: %v, y: %v", e.command, e.position.X, e.position.Y)
+}
+
+func (e *InvalidPositionError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type FlagBeforeOpenError struct{}
+
+func (e *FlagBeforeOpenError) Error() string {
+	return "can not flag a tile before the first tile is open"
+}
+
+func (e *FlagBeforeOpenError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type FlagsDisabledError struct{}
+
+func (e *FlagsDisabledError) Error() string {
+	return "flags are disabled in no flag mode"
+}
+
+func (e *FlagsDisabledError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type StrictNoFlagError struct{}
+
+func (e *StrictNoFlagError) Error() string {
+	return "the strict win condition needs every mine flagged so it can not be played with no flag"
+}
+
+func (e *StrictNoFlagError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidCommandError struct {
+	command CommandKind
+}
+
+func (e *InvalidCommandError) Error() string {
+	return fmt.Sprintf("invalid command \"%v\"", e.command)
+}
+
+func (e *InvalidCommandError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+// Session plays one game at a time driving the engine directly
+type Session struct {
+	defaults   Game
+	game       Game
+	gameEngine *gameengine.GameEngine
+	minesSet   bool
+}
+
+func CreateSession(defaults Game) *Session {
+	return &Session{defaults: defaults, game: defaults}
+}
+
+func (s *Session) newGame(command Command) error {
+	game := s.game
+	if command.Width != 0 {
+		game.Width = command.Width
+	}
+	if command.Height != 0 {
+		game.Height = command.Height
+	}
+	if command.Mines != 0 {
+		game.Mines = command.Mines
+	}
+	game.Seed = s.defaults.Seed
+	if command.Seed != 0 {
+		game.Seed = command.Seed
+	}
+
+	if game.NoFlag && game.WinCondition == winconditions.Strict {
+		return &StrictNoFlagError{}
+	}
+
+	gameEngine := &gameengine.GameEngine{}
+	if err := gameEngine.SetFieldSize(game.Width, game.Height); err != nil {
+		return err
+	}
+	if err := gameEngine.SetMineCount(game.Mines); err != nil {
+		return err
+	}
+	if err := gameEngine.SetLives(max(game.Lives, 1)); err != nil {
+		return err
+	}
+	gameEngine.SetWinCondition(game.WinCondition)
+	if game.Seed != 0 {
+		gameEngine.SetSeed(game.Seed)
+	}
+
+	s.game = game
+	s.gameEngine = gameEngine
+	s.minesSet = false
+	return nil
+}
+
+func (s *Session) act(command Command) error {
+	if s.gameEngine == nil {
+		return &NoGameError{}
+	}
+	if s.gameEngine.IsFinished() {
+		return &GameIsOverError{}
+	}
+
+	position := types.Position{X: command.X, Y: command.Y}
+	tile := s.gameEngine.GetTile(position)
+
+	switch command.Command {
+	case Open:
+		if tile != tiles.ClosedSafe && tile != tiles.ClosedMine {
+			return &InvalidPositionError{command.Command, position}
+		}
+		if !s.minesSet {
+			s.gameEngine.SetMines(position)
+			s.minesSet = true
+		}
+		s.gameEngine.Reveal(position)
+	case Chord:
+		if tile != tiles.OpenSafe {
+			return &InvalidPositionError{command.Command, position}
+		}
+		s.gameEngine.Reveal(position)
+	case Flag:
+		if s.game.NoFlag {
+			return &FlagsDisabledError{}
+		}
+		if !s.minesSet {
+			return &FlagBeforeOpenError{}
+		}
+		switch tile {
+		case tiles.OutOfBounds, tiles.OpenSafe, tiles.OpenMine:
+			return &InvalidPositionError{command.Command, position}
+		}
+		s.gameEngine.FlagToggleTile(position)
+	}
+	return nil
+}
+
+func (s *Session) Handle(command Command) Response {
+	var err error
+	switch command.Command {
+	case New:
+		err = s.newGame(command)
+	case Open, Flag, Chord:
+		err = s.act(command)
+	case State:
+		if s.gameEngine == nil {
+			err = &NoGameError{}
+		}
+	default:
+		err = &InvalidCommandError{command.Command}
+	}
+
+	if err != nil {
+		response := s.getState()
+		response.Error = err.Error()
+		return response
+	}
+	response := s.getState()
+	response.OK = true
+	return response
+}
+
+func (s *Session) getState() Response {
+	if s.gameEngine == nil {
+		return Response{}
+	}
+
+	response := Response{
+		Outcome: Playing,
+		Width:   s.game.Width,
+		Height:  s.game.Height,
+		Mines:   s.game.Mines,
+		Open:    s.gameEngine.GetOpenCount(),
+		Lives:   s.gameEngine.GetLives(),
+		Board:   make([]string, s.game.Height),
+	}
+	if s.gameEngine.IsFinished() {
+		response.Outcome = Lost
+		if s.gameEngine.IsWon() {
+			response.Outcome = Won
+		}
+	}
+
+	for y := range s.game.Height {
+		row := make([]byte, s.game.Width)
+		for x := range s.game.Width {
+			row[x] = s.getTileChar(types.Position{X: x, Y: y})
+			if row[x] == flagChar || row[x] == wrongFlagChar {
+				response.Flags++
+			}
+		}
+		response.Board[y] = string(row)
+	}
+	return response
+}
+
+func (s *Session) getTileChar(position types.Position) byte {
+	if s.gameEngine.IsFinished() {
+		switch s.gameEngine.GetTile(position) {
+		case tiles.ClosedMine:
+			return mineChar
+		case tiles.FlaggedSafe:
+			return wrongFlagChar
+		}
+	}
+
+	tileContent := s.gameEngine.GetTileContent(position)
+	switch tileContent {
+	case tilecontent.Empty:
+		return closedChar
+	case tilecontent.Flag:
+		return flagChar
+	case tilecontent.Mine:
+		return mineChar
+	default:
+		count, _ := tileContent.ToNumber()
+		return '0' + count
+	}
+}
+
+// Answers every command read from in with a response written to out
+// until in is exhausted
+func Run(in io.Reader, out io.Writer, defaults Game) error {
+	session := CreateSession(defaults)
+	scanner := bufio.NewScanner(in)
+	encoder := json.NewEncoder(out)
+
+	for scanner.Scan() {
+		var command Command
+		var response Response
+		if err := json.Unmarshal(scanner.Bytes(), &command); err != nil {
+			response = session.getState()
+			response.Error = err.Error()
+		} else {
+			response = session.Handle(command)
+		}
+		if err := encoder.Encode(response); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,203 @@
+package headless
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	tiles "sweep/shared/consts/tiles"
+	winconditions "sweep/shared/consts/win-conditions"
+	types "sweep/shared/types"
+)
+
+func TestHandle(t *testing.T) {
+	type testCase struct {
+		commands []Command
+		expected error
+	}
+
+	newGame := Command{Command: New, Width: 9, Height: 9, Mines: 10, Seed: 42}
+
+	testCases := []testCase{
+		{
+			commands: []Command{{Command: State}},
+			expected: &NoGameError{},
+		},
+		{
+			commands: []Command{{Command: Open, X: 4, Y: 4}},
+			expected: &NoGameError{},
+		},
+		{
+			commands: []Command{{Command: "dig"}},
+			expected: &InvalidCommandError{"dig"},
+		},
+		{
+			commands: []Command{{Command: New, Width: 2, Height: 2, Mines: 4}},
+			expected: errors.New("mine count must be less than field width multiplied by field height"),
+		},
+		{
+			commands: []Command{newGame, {Command: Flag, X: 0, Y: 0}},
+			expected: &FlagBeforeOpenError{},
+		},
+		{
+			commands: []Command{newGame, {Command: Chord, X: 4, Y: 4}},
+			expected: &InvalidPositionError{Chord, types.Position{X: 4, Y: 4}},
+		},
+		{
+			commands: []Command{newGame, {Command: Open, X: 4, Y: 4}, {Command: Open, X: 4, Y: 4}},
+			expected: &InvalidPositionError{Open, types.Position{X: 4, Y: 4}},
+		},
+		{
+			commands: []Command{newGame, {Command: Open, X: 4, Y: 4}, {Command: Flag, X: 4, Y: 4}},
+			expected: &InvalidPositionError{Flag, types.Position{X: 4, Y: 4}},
+		},
+		{
+			commands: []Command{newGame, {Command: Open, X: 9, Y: 0}},
+			expected: &InvalidPositionError{Open, types.Position{X: 9, Y: 0}},
+		},
+		{
+			commands: []Command{newGame, {Command: Open, X: 4, Y: 4}, {Command: State}},
+			expected: nil,
+		},
+	}
+
+	for ix, testCase := range testCases {
+		session := CreateSession(Game{})
+		var response Response
+		for _, command := range testCase.commands {
+			response = session.Handle(command)
+		}
+
+		if testCase.expected == nil {
+			if !response.OK || response.Error != "" {
+				t.Errorf("[Assertion failed] test case #%v\nExpected no error\nActual: %v", ix+1, response.Error)
+			}
+			continue
+		}
+		if response.OK || response.Error != testCase.expected.Error() {
+			t.Errorf("[Assertion failed] test case #%v\nExpected: %v\nActual: %v", ix+1, testCase.expected, response.Error)
+		}
+	}
+}
+
+func TestNoFlag(t *testing.T) {
+	session := CreateSession(Game{Width: 9, Height: 9, Mines: 10, NoFlag: true})
+	session.Handle(Command{Command: New})
+	session.Handle(Command{Command: Open, X: 4, Y: 4})
+
+	response := session.Handle(Command{Command: Flag, X: 0, Y: 0})
+	if response.Error != (&FlagsDisabledError{}).Error() {
+		t.Errorf("[Assertion failed]\nExpected: %v\nActual: %v", &FlagsDisabledError{}, response.Error)
+	}
+
+	strict := CreateSession(Game{Width: 9, Height: 9, Mines: 10, NoFlag: true, WinCondition: winconditions.Strict})
+	response = strict.Handle(Command{Command: New})
+	if response.OK || response.Error != (&StrictNoFlagError{}).Error() {
+		t.Errorf("[Assertion failed] strict win condition should not be played with no flag\nActual: %v", response.Error)
+	}
+}
+
+func countChar(board []string, char rune) int {
+	return strings.Count(strings.Join(board, ""), string(char))
+}
+
+// Plays whole games knowing where the mines are to check what the board shows on the way
+func TestBoard(t *testing.T) {
+	type testCase struct {
+		hitMine  bool
+		expected Outcome
+	}
+
+	testCases := []testCase{
+		{hitMine: false, expected: Won},
+		{hitMine: true, expected: Lost},
+	}
+
+	for ix, testCase := range testCases {
+		session := CreateSession(Game{})
+		session.Handle(Command{Command: New, Width: 9, Height: 9, Mines: 10, Seed: 7})
+		response := session.Handle(Command{Command: Open, X: 0, Y: 0})
+
+		var mine, safe *types.Position
+		for y := range uint16(9) {
+			for x := range uint16(9) {
+				position := types.Position{X: x, Y: y}
+				switch session.gameEngine.GetTile(position) {
+				case tiles.ClosedMine:
+					mine = &position
+				case tiles.ClosedSafe:
+					safe = &position
+				}
+			}
+		}
+		session.Handle(Command{Command: Flag, X: safe.X, Y: safe.Y})
+
+		for y := range uint16(9) {
+			for x := range uint16(9) {
+				if response.Outcome != Playing {
+					break
+				}
+				if countChar(response.Board, mineChar) != 0 {
+					t.Fatalf("[Assertion failed] test case #%v board leaks a mine\n%v", ix+1, strings.Join(response.Board, "\n"))
+				}
+				position := types.Position{X: x, Y: y}
+				if testCase.hitMine && position == *mine {
+					response = session.Handle(Command{Command: Open, X: x, Y: y})
+					continue
+				}
+				if session.gameEngine.GetTile(position) == tiles.ClosedSafe && position != *safe {
+					response = session.Handle(Command{Command: Open, X: x, Y: y})
+				}
+			}
+		}
+		if response.Outcome == Playing {
+			response = session.Handle(Command{Command: Flag, X: safe.X, Y: safe.Y})
+			response = session.Handle(Command{Command: Open, X: safe.X, Y: safe.Y})
+		}
+
+		if response.Outcome != testCase.expected {
+			t.Errorf("[Assertion failed] test case #%v\nExpected: %v\nActual: %v", ix+1, testCase.expected, response.Outcome)
+		}
+		if mines := countChar(response.Board, mineChar); mines != 10 {
+			t.Errorf("[Assertion failed] test case #%v mines are not revealed once the game is over\nExpected: 10\nActual: %v", ix+1, mines)
+		}
+		if testCase.hitMine && countChar(response.Board, wrongFlagChar) != 1 {
+			t.Errorf("[Assertion failed] test case #%v wrong flag is not revealed\n%v", ix+1, strings.Join(response.Board, "\n"))
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	in := strings.NewReader(strings.Join([]string{
+		`{"command":"new","width":9,"height":9,"mines":10,"seed":42}`,
+		`{"command":"open","x":4,"y":4}`,
+		`not json`,
+		`{"command":"state"}`,
+	}, "\n"))
+	var out strings.Builder
+
+	if err := Run(in, &out, Game{}); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOK := []bool{true, true, false, true}
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	ix := 0
+	for ; scanner.Scan(); ix++ {
+		var response Response
+		if err := json.Unmarshal(scanner.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		if ix < len(expectedOK) && response.OK != expectedOK[ix] {
+			t.Errorf("[Assertion failed] response #%v\nExpected ok: %v\nActual: %v", ix+1, expectedOK[ix], response.OK)
+		}
+		if len(response.Board) != 9 || len(response.Board[0]) != 9 {
+			t.Errorf("[Assertion failed] response #%v board is not 9x9\n%v", ix+1, response.Board)
+		}
+	}
+	if ix != len(expectedOK) {
+		t.Errorf("[Assertion failed]\nExpected %v responses\nActual: %v", len(expectedOK), ix)
+	}
+}
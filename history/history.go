@@ -0,0 +1,163 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	paths "sweep/shared/vars/paths"
+)
+
+type Record struct {
+	Date     time.Time     `json:"date"`
+	Width    uint16        `json:"width"`
+	Height   uint16        `json:"height"`
+	Mines    uint16        `json:"mines"`
+	Won      bool          `json:"won"`
+	Duration time.Duration `json:"duration"`
+	// Whether not a single flag was set during the game
+	NoFlags bool `json:"no flags"`
+}
+
+type History []Record
+
+type HistoryReadError struct {
+	readFileErr error
+}
+
+func (e *HistoryReadError) Error() string {
+	return fmt.Sprintf("could not read game history \"%v\": %v", paths.HistoryPath, e.readFileErr)
+}
+
+func (e *HistoryReadError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type HistoryParsingError struct {
+	unmarshalError error
+}
+
+func (e *HistoryParsingError) Error() string {
+	return fmt.Sprintf("could not parse game history \"%v\": %v", paths.HistoryPath, e.unmarshalError)
+}
+
+func (e *HistoryParsingError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type HistoryWriteError struct {
+	writeFileErr error
+}
+
+func (e *HistoryWriteError) Error() string {
+	return fmt.Sprintf("could not write game history \"%v\": do you have the right permissions?", paths.HistoryPath)
+}
+
+func (e *HistoryWriteError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+// Returns an empty history if the history file does not exist yet
+func Load() (History, error) {
+	historyBin, err := os.ReadFile(paths.HistoryPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return History{}, nil
+	}
+	if err != nil {
+		return nil, &HistoryReadError{err}
+	}
+
+	var history History
+	if err = json.Unmarshal(historyBin, &history); err != nil {
+		return nil, &HistoryParsingError{err}
+	}
+
+	return history, nil
+}
+
+func Save(record Record) error {
+	history, err := Load()
+	if err != nil {
+		return err
+	}
+	history = append(history, record)
+
+	historyBin, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return &HistoryWriteError{err}
+	}
+
+	if err = os.WriteFile(paths.HistoryPath, historyBin, 0666); err != nil {
+		return &HistoryWriteError{err}
+	}
+
+	return nil
+}
+
+// Returns won games on the field of the same size and mine count
+// fastest first. No flag runs are kept apart from flagging runs
+func (h History) Leaderboard(width, height, mines uint16, noFlags bool) History {
+	leaderboard := History{}
+	for _, record := range h {
+		if !record.Won || record.NoFlags != noFlags {
+			continue
+		}
+		if record.Width != width || record.Height != height || record.Mines != mines {
+			continue
+		}
+		leaderboard = append(leaderboard, record)
+	}
+
+	slices.SortStableFunc(leaderboard, func(a, b Record) int {
+		return int(a.Duration - b.Duration)
+	})
+
+	return leaderboard
+}
+
+// Games played on the fields of the same size and mine count
+type FieldStats struct {
+	Width  uint16
+	Height uint16
+	Mines  uint16
+	Played int
+	Won    int
+	// Fastest wins with and without flags, 0 while there is none
+	Best        time.Duration
+	BestNoFlags time.Duration
+}
+
+// Groups the games by their field, the fields played the most first
+func (h History) GetFieldStats() []FieldStats {
+	var stats []FieldStats
+	for _, record := range h {
+		ix := slices.IndexFunc(stats, func(s FieldStats) bool {
+			return s.Width == record.Width && s.Height == record.Height && s.Mines == record.Mines
+		})
+		if ix == -1 {
+			stats = append(stats, FieldStats{Width: record.Width, Height: record.Height, Mines: record.Mines})
+			ix = len(stats) - 1
+		}
+		field := &stats[ix]
+		field.Played++
+		if !record.Won {
+			continue
+		}
+		field.Won++
+		best := &field.Best
+		if record.NoFlags {
+			best = &field.BestNoFlags
+		}
+		if *best == 0 || record.Duration < *best {
+			*best = record.Duration
+		}
+	}
+
+	slices.SortStableFunc(stats, func(a, b FieldStats) int {
+		return b.Played - a.Played
+	})
+	return stats
+}
@@ -0,0 +1,70 @@
+package history
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func Test_Leaderboard(t *testing.T) {
+	history := History{
+		{Width: 9, Height: 9, Mines: 10, Won: true, Duration: 30 * time.Second, NoFlags: false},
+		{Width: 9, Height: 9, Mines: 10, Won: true, Duration: 20 * time.Second, NoFlags: true},
+		{Width: 9, Height: 9, Mines: 10, Won: false, Duration: 5 * time.Second, NoFlags: true},
+		{Width: 9, Height: 9, Mines: 10, Won: true, Duration: 10 * time.Second, NoFlags: false},
+		{Width: 16, Height: 16, Mines: 40, Won: true, Duration: 1 * time.Second, NoFlags: false},
+		{Width: 9, Height: 9, Mines: 10, Won: true, Duration: 15 * time.Second, NoFlags: true},
+	}
+
+	type TestCase struct {
+		noFlags  bool
+		expected []time.Duration
+	}
+
+	testCases := []TestCase{
+		{
+			noFlags:  false,
+			expected: []time.Duration{10 * time.Second, 30 * time.Second},
+		},
+		{
+			noFlags:  true,
+			expected: []time.Duration{15 * time.Second, 20 * time.Second},
+		},
+	}
+
+	for n, testCase := range testCases {
+		leaderboard := history.Leaderboard(9, 9, 10, testCase.noFlags)
+		if len(leaderboard) != len(testCase.expected) {
+			t.Errorf("[Assertion failed] #%v leaderboard length\nExpected: %v\nActual: %v", n+1, len(testCase.expected), len(leaderboard))
+			continue
+		}
+		for ix, record := range leaderboard {
+			if record.Duration != testCase.expected[ix] {
+				t.Errorf("[Assertion failed] #%v place %v\nExpected: %v\nActual: %v", n+1, ix+1, testCase.expected[ix], record.Duration)
+			}
+			if record.NoFlags != testCase.noFlags {
+				t.Errorf("[Assertion failed] #%v place %v should not be in the leaderboard", n+1, ix+1)
+			}
+		}
+	}
+}
+
+func Test_GetFieldStats(t *testing.T) {
+	history := History{
+		{Width: 16, Height: 16, Mines: 40, Won: false, Duration: 3 * time.Second},
+		{Width: 9, Height: 9, Mines: 10, Won: true, Duration: 30 * time.Second, NoFlags: false},
+		{Width: 9, Height: 9, Mines: 10, Won: true, Duration: 20 * time.Second, NoFlags: true},
+		{Width: 9, Height: 9, Mines: 10, Won: false, Duration: 5 * time.Second, NoFlags: true},
+		{Width: 9, Height: 9, Mines: 10, Won: true, Duration: 10 * time.Second, NoFlags: false},
+	}
+
+	expected := []FieldStats{
+		{Width: 9, Height: 9, Mines: 10, Played: 4, Won: 3, Best: 10 * time.Second, BestNoFlags: 20 * time.Second},
+		{Width: 16, Height: 16, Mines: 40, Played: 1, Won: 0},
+	}
+
+	actual := history.GetFieldStats()
+	if !slices.Equal(actual, expected) {
+		t.Errorf("[Assertion failed]\nExpected: %v\nActual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,328 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	headless "sweep/headless"
+
+	log "github.com/charmbracelet/log"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// Game wraps a headless session so it could be played by several requests at once
+// and streamed to every subscriber after each change
+type game struct {
+	mu          sync.Mutex
+	session     *headless.Session
+	state       headless.Response
+	subscribers map[chan headless.Response]struct{}
+	// Closed once the game is deleted so the streams of its subscribers end
+	deleted chan struct{}
+}
+
+func (g *game) handle(command headless.Command) headless.Response {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	response := g.session.Handle(command)
+	if response.OK {
+		g.state = response
+		for subscriber := range g.subscribers {
+			// Only the latest state matters to a slow subscriber
+			select {
+			case <-subscriber:
+			default:
+			}
+			subscriber <- response
+		}
+	}
+	return response
+}
+
+func (g *game) getState() headless.Response {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.state
+}
+
+func (g *game) subscribe() (chan headless.Response, headless.Response) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	subscriber := make(chan headless.Response, 1)
+	g.subscribers[subscriber] = struct{}{}
+	return subscriber, g.state
+}
+
+func (g *game) unsubscribe(subscriber chan headless.Response) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.subscribers, subscriber)
+}
+
+type server struct {
+	mu       sync.RWMutex
+	defaults headless.Game
+	games    map[int]*game
+	nextID   int
+}
+
+// Game returned from the API along with its ID
+type GameResponse struct {
+	ID int `json:"id"`
+	headless.Response
+}
+
+type GameNotFoundError struct {
+	id string
+}
+
+func (e *GameNotFoundError) Error() string {
+	return fmt.Sprintf("game \"%v\" was not found", e.id)
+}
+
+func (e *GameNotFoundError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidActionError struct {
+	action headless.CommandKind
+}
+
+func (e *InvalidActionError) Error() string {
+	return fmt.Sprintf("invalid action \"%v\", expected open, flag or chord", e.action)
+}
+
+func (e *InvalidActionError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+// Creates the handler of the API, games are created with the defaults unless the request overrides them
+//
+//	POST   /games                  creates a game, the body is the same as of the headless "new" command
+//	GET    /games                  lists IDs of the games
+//	GET    /games/{id}             returns the state of the game
+//	DELETE /games/{id}             removes the game
+//	POST   /games/{id}/{action}    opens, flags or chords the tile at {"x", "y"} of the body
+//	GET    /games/{id}/events      streams the state after every change as server-sent events
+func CreateHandler(defaults headless.Game) http.Handler {
+	s := &server{
+		defaults: defaults,
+		games:    map[int]*game{},
+		nextID:   1,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /games", s.createGame)
+	mux.HandleFunc("GET /games", s.listGames)
+	mux.HandleFunc("GET /games/{id}", s.getGame)
+	mux.HandleFunc("DELETE /games/{id}", s.deleteGame)
+	mux.HandleFunc("POST /games/{id}/{action}", s.act)
+	mux.HandleFunc("GET /games/{id}/events", s.streamEvents)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, headless.Response{Error: err.Error()})
+}
+
+// Empty bodies are allowed since every field is optional
+func decodeCommand(r *http.Request) (headless.Command, error) {
+	var command headless.Command
+	err := json.NewDecoder(r.Body).Decode(&command)
+	if errors.Is(err, io.EOF) {
+		return command, nil
+	}
+	return command, err
+}
+
+func (s *server) getGameByID(w http.ResponseWriter, r *http.Request) (int, *game) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, &GameNotFoundError{r.PathValue("id")})
+		return id, nil
+	}
+	s.mu.RLock()
+	g, ok := s.games[id]
+	s.mu.RUnlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, &GameNotFoundError{r.PathValue("id")})
+		return id, nil
+	}
+	return id, g
+}
+
+func (s *server) createGame(w http.ResponseWriter, r *http.Request) {
+	command, err := decodeCommand(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	command.Command = headless.New
+
+	g := &game{
+		session:     headless.CreateSession(s.defaults),
+		subscribers: map[chan headless.Response]struct{}{},
+		deleted:     make(chan struct{}),
+	}
+	response := g.handle(command)
+	if !response.OK {
+		writeJSON(w, http.StatusBadRequest, response)
+		return
+	}
+
+	s.mu.Lock()
+	id := s.nextID
+	s.nextID++
+	s.games[id] = g
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, GameResponse{id, response})
+}
+
+func (s *server) listGames(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	ids := slices.Sorted(maps.Keys(s.games))
+	s.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, ids)
+}
+
+func (s *server) getGame(w http.ResponseWriter, r *http.Request) {
+	id, g := s.getGameByID(w, r)
+	if g == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, GameResponse{id, g.getState()})
+}
+
+func (s *server) deleteGame(w http.ResponseWriter, r *http.Request) {
+	id, g := s.getGameByID(w, r)
+	if g == nil {
+		return
+	}
+	s.mu.Lock()
+	_, ok := s.games[id]
+	delete(s.games, id)
+	s.mu.Unlock()
+	// Concurrent requests may both find the game but only one of them closes it
+	if ok {
+		close(g.deleted)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) act(w http.ResponseWriter, r *http.Request) {
+	id, g := s.getGameByID(w, r)
+	if g == nil {
+		return
+	}
+
+	command, err := decodeCommand(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	command.Command = headless.CommandKind(r.PathValue("action"))
+	switch command.Command {
+	case headless.Open, headless.Flag, headless.Chord:
+	default:
+		writeError(w, http.StatusNotFound, &InvalidActionError{command.Command})
+		return
+	}
+
+	response := g.handle(command)
+	status := http.StatusOK
+	if !response.OK {
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, GameResponse{id, response})
+}
+
+func (s *server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	_, g := s.getGameByID(w, r)
+	if g == nil {
+		return
+	}
+
+	subscriber, state := g.subscribe()
+	defer g.unsubscribe(subscriber)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	writeEvent := func(state headless.Response) error {
+		data, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(w, "event: state\ndata: %s\n\n", data); err != nil {
+			return err
+		}
+		http.NewResponseController(w).Flush()
+		return nil
+	}
+
+	if err := writeEvent(state); err != nil {
+		return
+	}
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-g.deleted:
+			return
+		case state := <-subscriber:
+			if err := writeEvent(state); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Serves the API until the process is interrupted
+func Serve(address string, defaults headless.Game) error {
+	server := &http.Server{
+		Addr:    address,
+		Handler: CreateHandler(defaults),
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+
+	log.Info("serving over http", "address", address)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("could not serve", "error", err)
+			done <- nil
+		}
+	}()
+
+	<-done
+	log.Info("stopping the server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
@@ -0,0 +1,160 @@
+package httpserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	headless "sweep/headless"
+)
+
+// Fails the test without stopping it so it could be used from other goroutines
+func request(t *testing.T, server *httptest.Server, method, path, body string) (int, GameResponse) {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Error(err)
+		return 0, GameResponse{}
+	}
+	res, err := server.Client().Do(req)
+	if err != nil {
+		t.Error(err)
+		return 0, GameResponse{}
+	}
+	defer res.Body.Close()
+
+	var response GameResponse
+	json.NewDecoder(res.Body).Decode(&response)
+	return res.StatusCode, response
+}
+
+func TestAPI(t *testing.T) {
+	server := httptest.NewServer(CreateHandler(headless.Game{Width: 9, Height: 9, Mines: 10}))
+	defer server.Close()
+
+	type testCase struct {
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}
+
+	testCases := []testCase{
+		{method: "POST", path: "/games", body: `{"seed":42}`, expectedStatus: http.StatusCreated},
+		{method: "POST", path: "/games", body: `{"width":2,"height":2,"mines":4}`, expectedStatus: http.StatusBadRequest},
+		{method: "POST", path: "/games", body: `not json`, expectedStatus: http.StatusBadRequest},
+		{method: "GET", path: "/games/1", expectedStatus: http.StatusOK},
+		{method: "GET", path: "/games/2", expectedStatus: http.StatusNotFound},
+		{method: "GET", path: "/games/one", expectedStatus: http.StatusNotFound},
+		{method: "POST", path: "/games/1/flag", body: `{"x":0,"y":0}`, expectedStatus: http.StatusConflict},
+		{method: "POST", path: "/games/1/open", body: `{"x":4,"y":4}`, expectedStatus: http.StatusOK},
+		{method: "POST", path: "/games/1/open", body: `{"x":4,"y":4}`, expectedStatus: http.StatusConflict},
+		{method: "POST", path: "/games/1/new", body: `{}`, expectedStatus: http.StatusNotFound},
+		{method: "DELETE", path: "/games/1", expectedStatus: http.StatusNoContent},
+		{method: "GET", path: "/games/1", expectedStatus: http.StatusNotFound},
+	}
+
+	for ix, testCase := range testCases {
+		status, response := request(t, server, testCase.method, testCase.path, testCase.body)
+		if status != testCase.expectedStatus {
+			t.Errorf("[Assertion failed] test case #%v %v %v\nExpected: %v\nActual: %v\nResponse: %+v", ix+1, testCase.method, testCase.path, testCase.expectedStatus, status, response)
+		}
+	}
+}
+
+func TestConcurrentGames(t *testing.T) {
+	server := httptest.NewServer(CreateHandler(headless.Game{Width: 16, Height: 16, Mines: 40}))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			_, created := request(t, server, "POST", "/games", "")
+			_, opened := request(t, server, "POST", "/games/"+strconv.Itoa(created.ID)+"/open", `{"x":8,"y":8}`)
+			if opened.ID != created.ID || opened.Open == 0 {
+				t.Errorf("[Assertion failed] game #%v was not opened\nResponse: %+v", created.ID, opened)
+			}
+		})
+	}
+	wg.Wait()
+
+	res, err := server.Client().Get(server.URL + "/games")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var ids []int
+	json.NewDecoder(res.Body).Decode(&ids)
+	if len(ids) != 8 {
+		t.Errorf("[Assertion failed]\nExpected 8 games\nActual: %v", ids)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	server := httptest.NewServer(CreateHandler(headless.Game{Width: 9, Height: 9, Mines: 10}))
+	defer server.Close()
+
+	request(t, server, "POST", "/games", `{"seed":42}`)
+
+	res, err := server.Client().Get(server.URL + "/games/1/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if contentType := res.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("[Assertion failed]\nExpected: text/event-stream\nActual: %v", contentType)
+	}
+
+	events := make(chan headless.Response)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(res.Body)
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var state headless.Response
+			json.Unmarshal([]byte(data), &state)
+			events <- state
+		}
+	}()
+
+	receive := func() headless.Response {
+		t.Helper()
+		select {
+		case state := <-events:
+			return state
+		case <-time.After(2 * time.Second):
+			t.Fatal("[Assertion failed] no event was received in time")
+			return headless.Response{}
+		}
+	}
+
+	if state := receive(); state.Open != 0 {
+		t.Errorf("[Assertion failed] initial state\nExpected no open tiles\nActual: %v", state.Open)
+	}
+
+	request(t, server, "POST", "/games/1/open", `{"x":4,"y":4}`)
+	if state := receive(); state.Open == 0 {
+		t.Errorf("[Assertion failed] state after open\nExpected open tiles\nActual: %v", state.Open)
+	}
+
+	request(t, server, "DELETE", "/games/1", "")
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Errorf("[Assertion failed] no event should be sent after the game is deleted")
+		}
+	case <-time.After(2 * time.Second):
+		t.Errorf("[Assertion failed] the stream should end once the game is deleted")
+	}
+}
@@ -0,0 +1,91 @@
+package race
+
+import (
+	"net"
+	"sync"
+
+	jsonlines "sweep/shared/jsonlines"
+)
+
+var _ Peer = (*Client)(nil)
+
+type Client struct {
+	conn      *jsonlines.Conn
+	id        int
+	updates   *latest
+	start     chan Game
+	closeOnce sync.Once
+}
+
+// Connects to the host and waits to be welcomed
+func Join(address string, name string) (*Client, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, &ConnectionError{address, err}
+	}
+
+	c := &Client{
+		conn:    jsonlines.NewConn(conn),
+		updates: newLatest(),
+		start:   make(chan Game, 1),
+	}
+
+	if err = c.conn.Send(message{Kind: joinMessage, Name: name}); err != nil {
+		conn.Close()
+		return nil, &ConnectionError{address, err}
+	}
+
+	var msg message
+	if err = c.conn.Receive(&msg); err != nil {
+		conn.Close()
+		return nil, &ConnectionError{address, err}
+	}
+	if msg.Kind != welcomeMessage {
+		conn.Close()
+		return nil, &UnexpectedMessageError{welcomeMessage, msg.Kind}
+	}
+	c.id = msg.ID
+
+	go c.listen()
+
+	return c, nil
+}
+
+func (c *Client) listen() {
+	jsonlines.Listen(c.conn, func(msg message) {
+		switch msg.Kind {
+		case startMessage:
+			if msg.Game != nil {
+				c.start <- *msg.Game
+			}
+		case standingsMessage:
+			c.updates.push(msg.Standings)
+		}
+	})
+	c.closeOnce.Do(func() {
+		close(c.start)
+	})
+}
+
+func (c *Client) GetID() int {
+	return c.id
+}
+
+// Receives the field parameters once the host starts the race
+// The channel is closed if the host is gone before that
+func (c *Client) Started() <-chan Game {
+	return c.start
+}
+
+func (c *Client) Report(progress Progress) error {
+	progress.ID = c.id
+	return c.conn.Send(message{Kind: progressMessage, Progress: &progress})
+}
+
+func (c *Client) Standings() <-chan []Progress {
+	return c.updates.ch
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
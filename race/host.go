@@ -0,0 +1,216 @@
+package race
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	jsonlines "sweep/shared/jsonlines"
+)
+
+var _ Peer = (*Host)(nil)
+
+// Host accepts players, starts the race and relays everyone's progress
+// The host is a player as well and always has the ID of 0
+type Host struct {
+	mu        sync.Mutex
+	listener  net.Listener
+	clients   map[int]*jsonlines.Conn
+	standings []Progress
+	nextID    int
+	started   bool
+	updates   *latest
+	// Signalled whenever a player makes progress or leaves
+	changed chan struct{}
+}
+
+func Listen(address string, name string) (*Host, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, &ConnectionError{address, err}
+	}
+
+	h := &Host{
+		listener:  listener,
+		clients:   map[int]*jsonlines.Conn{},
+		standings: []Progress{{ID: 0, Name: name, Alive: true}},
+		nextID:    1,
+		updates:   newLatest(),
+		changed:   make(chan struct{}, 1),
+	}
+	h.updates.push(h.getStandings())
+
+	go h.accept()
+
+	return h, nil
+}
+
+func (h *Host) GetAddress() string {
+	return h.listener.Addr().String()
+}
+
+func (h *Host) GetID() int {
+	return 0
+}
+
+func (h *Host) accept() {
+	for {
+		conn, err := h.listener.Accept()
+		if err != nil {
+			return
+		}
+		go h.serve(conn)
+	}
+}
+
+func (h *Host) serve(conn net.Conn) {
+	client := jsonlines.NewConn(conn)
+	defer client.Close()
+
+	var msg message
+	if err := client.Receive(&msg); err != nil || msg.Kind != joinMessage {
+		return
+	}
+
+	h.mu.Lock()
+	if h.started {
+		h.mu.Unlock()
+		return
+	}
+	id := h.nextID
+	h.nextID++
+	h.clients[id] = client
+	h.standings = append(h.standings, Progress{ID: id, Name: msg.Name, Alive: true})
+	h.mu.Unlock()
+
+	if err := client.Send(message{Kind: welcomeMessage, ID: id}); err != nil {
+		h.disconnect(id)
+		return
+	}
+	h.broadcast()
+
+	jsonlines.Listen(client, func(msg message) {
+		if msg.Kind == progressMessage && msg.Progress != nil {
+			h.update(id, *msg.Progress)
+		}
+	})
+
+	h.disconnect(id)
+}
+
+func (h *Host) disconnect(id int) {
+	h.mu.Lock()
+	delete(h.clients, id)
+	h.mu.Unlock()
+	h.notify()
+}
+
+// A pending signal is enough for the waiter to check again so the rest are dropped
+func (h *Host) notify() {
+	select {
+	case h.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Tells if every player still connected has finished the race
+func (h *Host) isOver() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, progress := range h.standings {
+		if _, ok := h.clients[progress.ID]; ok && !progress.Finished {
+			return false
+		}
+	}
+	return true
+}
+
+// Keeps relaying the progress until every player still connected has finished the race
+// so the host leaving their own game does not end it for everyone else
+// The race is ended anyway once nobody has made progress for the idle time or the context is done
+func (h *Host) WaitForPlayers(ctx context.Context, idle time.Duration) {
+	for !h.isOver() {
+		select {
+		case <-h.changed:
+		case <-time.After(idle):
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (h *Host) update(id int, progress Progress) {
+	h.mu.Lock()
+	for ix := range h.standings {
+		if h.standings[ix].ID == id {
+			progress.ID = id
+			progress.Name = h.standings[ix].Name
+			h.standings[ix] = progress
+		}
+	}
+	h.mu.Unlock()
+
+	h.broadcast()
+	h.notify()
+}
+
+func (h *Host) getStandings() []Progress {
+	return slices.Clone(h.standings)
+}
+
+func (h *Host) broadcast() {
+	h.mu.Lock()
+	standingsCopy := h.getStandings()
+	clients := make([]*jsonlines.Conn, 0, len(h.clients))
+	for _, client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+
+	for _, client := range clients {
+		client.Send(message{Kind: standingsMessage, Standings: standingsCopy})
+	}
+	h.updates.push(standingsCopy)
+}
+
+// Sends the field parameters to every joined player
+// No one can join once the race has started
+func (h *Host) Start(game Game) error {
+	h.mu.Lock()
+	if h.started {
+		h.mu.Unlock()
+		return ErrRaceStarted
+	}
+	h.started = true
+	clients := make([]*jsonlines.Conn, 0, len(h.clients))
+	for _, client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+
+	for _, client := range clients {
+		client.Send(message{Kind: startMessage, Game: &game})
+	}
+	return nil
+}
+
+func (h *Host) Report(progress Progress) error {
+	h.update(h.GetID(), progress)
+	return nil
+}
+
+func (h *Host) Standings() <-chan []Progress {
+	return h.updates.ch
+}
+
+func (h *Host) Close() error {
+	h.mu.Lock()
+	for _, client := range h.clients {
+		client.Close()
+	}
+	h.mu.Unlock()
+	return h.listener.Close()
+}
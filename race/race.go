@@ -0,0 +1,119 @@
+package race
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"sync"
+	"time"
+
+	winconditions "sweep/shared/consts/win-conditions"
+)
+
+type messageKind string
+
+const (
+	joinMessage      messageKind = "join"
+	welcomeMessage   messageKind = "welcome"
+	startMessage     messageKind = "start"
+	progressMessage  messageKind = "progress"
+	standingsMessage messageKind = "standings"
+)
+
+// Parameters every player needs to generate the very same field and play it by the same rules
+type Game struct {
+	Seed         int64                      `json:"seed"`
+	Width        uint16                     `json:"width"`
+	Height       uint16                     `json:"height"`
+	Mines        uint16                     `json:"mines"`
+	Lives        uint16                     `json:"lives"`
+	TimeLimit    uint16                     `json:"time limit"`
+	TimeBonus    uint16                     `json:"time bonus"`
+	WinCondition winconditions.WinCondition `json:"win condition"`
+	NoFlag       bool                       `json:"no flag"`
+}
+
+type Progress struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Percent  uint8         `json:"percent"`
+	Alive    bool          `json:"alive"`
+	Finished bool          `json:"finished"`
+	Duration time.Duration `json:"duration"`
+}
+
+type message struct {
+	Kind      messageKind `json:"kind"`
+	ID        int         `json:"id,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	Game      *Game       `json:"game,omitempty"`
+	Progress  *Progress   `json:"progress,omitempty"`
+	Standings []Progress  `json:"standings,omitempty"`
+}
+
+// Returns the name of the current OS user to show to other players
+func GetPlayerName() string {
+	current, err := user.Current()
+	if err != nil || current.Username == "" {
+		return "player"
+	}
+	return current.Username
+}
+
+// Peer is a participant of the race, either the host or a client
+type Peer interface {
+	// Sends the progress of the local player to everyone else
+	Report(Progress) error
+	// Receives standings of every player whenever anyone makes progress
+	Standings() <-chan []Progress
+	GetID() int
+	Close() error
+}
+
+// Keeps only the latest standings so a slow reader never blocks the network
+type latest struct {
+	mu sync.Mutex
+	ch chan []Progress
+}
+
+func newLatest() *latest {
+	return &latest{ch: make(chan []Progress, 1)}
+}
+
+func (l *latest) push(standings []Progress) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-l.ch:
+	default:
+	}
+	l.ch <- standings
+}
+
+type ConnectionError struct {
+	address string
+	err     error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("could not connect to \"%v\": %v", e.address, e.err)
+}
+
+func (e *ConnectionError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type UnexpectedMessageError struct {
+	expected messageKind
+	actual   messageKind
+}
+
+func (e *UnexpectedMessageError) Error() string {
+	return fmt.Sprintf("expected \"%v\" message from the host, received \"%v\"", e.expected, e.actual)
+}
+
+func (e *UnexpectedMessageError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+var ErrRaceStarted = errors.New("the race has already started")
@@ -0,0 +1,187 @@
+package race
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	winconditions "sweep/shared/consts/win-conditions"
+)
+
+const timeout = 2 * time.Second
+
+// Waits for standings matching the condition ignoring the intermediate ones
+func waitForStandings(t *testing.T, peer Peer, condition func([]Progress) bool) []Progress {
+	t.Helper()
+	deadline := time.After(timeout)
+	for {
+		select {
+		case standings := <-peer.Standings():
+			if condition(standings) {
+				return standings
+			}
+		case <-deadline:
+			t.Fatalf("[Assertion failed] peer #%v did not receive expected standings in time", peer.GetID())
+			return nil
+		}
+	}
+}
+
+func TestRace(t *testing.T) {
+	host, err := Listen("127.0.0.1:0", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+
+	first, err := Join(host.GetAddress(), "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	second, err := Join(host.GetAddress(), "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	if first.GetID() == second.GetID() || first.GetID() == host.GetID() {
+		t.Errorf("[Assertion failed] IDs are not unique\nhost: %v, first: %v, second: %v", host.GetID(), first.GetID(), second.GetID())
+	}
+
+	waitForStandings(t, host, func(standings []Progress) bool {
+		return len(standings) == 3
+	})
+
+	game := Game{Seed: 42, Width: 9, Height: 9, Mines: 10, Lives: 3, TimeLimit: 60, TimeBonus: 2, WinCondition: winconditions.Strict}
+	if err := host.Start(game); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, client := range []*Client{first, second} {
+		select {
+		case actual := <-client.Started():
+			if actual != game {
+				t.Errorf("[Assertion failed] client #%v game\nExpected: %v\nActual: %v", client.GetID(), game, actual)
+			}
+		case <-time.After(timeout):
+			t.Fatalf("[Assertion failed] client #%v did not receive the start in time", client.GetID())
+		}
+	}
+
+	if _, err := Join(host.GetAddress(), "late"); err == nil {
+		t.Errorf("[Assertion failed] joining a started race should fail")
+	}
+
+	err = first.Report(Progress{Percent: 50, Alive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, peer := range []Peer{host, second} {
+		waitForStandings(t, peer, func(standings []Progress) bool {
+			for _, progress := range standings {
+				if progress.ID == first.GetID() && progress.Percent == 50 {
+					return progress.Name == "first"
+				}
+			}
+			return false
+		})
+	}
+
+	host.Report(Progress{Percent: 100, Alive: true, Finished: true})
+	waitForStandings(t, first, func(standings []Progress) bool {
+		return standings[0].ID == host.GetID() && standings[0].Finished
+	})
+}
+
+func TestWaitForPlayers(t *testing.T) {
+	host, err := Listen("127.0.0.1:0", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+
+	first, err := Join(host.GetAddress(), "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	second, err := Join(host.GetAddress(), "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitForStandings(t, host, func(standings []Progress) bool {
+		return len(standings) == 3
+	})
+	if err := host.Start(Game{Seed: 42, Width: 9, Height: 9, Mines: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		host.WaitForPlayers(context.Background(), time.Minute)
+		close(done)
+	}()
+
+	first.Report(Progress{Percent: 100, Alive: true, Finished: true})
+	waitForStandings(t, host, func(standings []Progress) bool {
+		return standings[1].Finished
+	})
+	select {
+	case <-done:
+		t.Fatalf("[Assertion failed] the host should wait for the player still playing")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A player leaving is not waited for
+	second.Close()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatalf("[Assertion failed] the host should stop waiting once every player is done")
+	}
+}
+
+func TestWaitForStalledPlayers(t *testing.T) {
+	host, err := Listen("127.0.0.1:0", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+
+	client, err := Join(host.GetAddress(), "stalled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	waitForStandings(t, host, func(standings []Progress) bool {
+		return len(standings) == 2
+	})
+	if err := host.Start(Game{Seed: 42, Width: 9, Height: 9, Mines: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The player is still connected but never finishes
+	wait := func(ctx context.Context, idle time.Duration) {
+		done := make(chan struct{})
+		go func() {
+			host.WaitForPlayers(ctx, idle)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			t.Fatalf("[Assertion failed] the host should stop waiting for the stalled player")
+		}
+	}
+	wait(context.Background(), 100*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	wait(ctx, time.Minute)
+}
@@ -0,0 +1,220 @@
+package saves
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	actions "sweep/shared/consts/actions"
+	winconditions "sweep/shared/consts/win-conditions"
+	types "sweep/shared/types"
+	paths "sweep/shared/vars/paths"
+)
+
+const extension = ".json"
+
+// The game left unfinished on quitting is saved under this name so it could be continued from the menu
+const LastGame = "last"
+
+var nameRegex = regexp.MustCompile(`^[\w-]+$`)
+
+// An action taken on the field, replaying them on the same seed brings the game back
+type Move struct {
+	Action   actions.ActionType `json:"action"`
+	Position types.Position     `json:"position"`
+}
+
+type Game struct {
+	Width        uint16                     `json:"width"`
+	Height       uint16                     `json:"height"`
+	Mines        uint16                     `json:"mines"`
+	Lives        uint16                     `json:"lives"`
+	Players      uint16                     `json:"players"`
+	TimeLimit    uint16                     `json:"time limit"`
+	TimeBonus    uint16                     `json:"time bonus"`
+	WinCondition winconditions.WinCondition `json:"win condition"`
+	NoFlag       bool                       `json:"no flag"`
+	Seed         int64                      `json:"seed"`
+	Moves        []Move                     `json:"moves"`
+	Cursor       types.Position             `json:"cursor"`
+	Duration     time.Duration              `json:"duration"`
+}
+
+type InvalidSaveNameError struct {
+	name string
+}
+
+func (e *InvalidSaveNameError) Error() string {
+	return fmt.Sprintf("\"%v\" is not a valid save name, use letters, digits, - and _", e.name)
+}
+
+func (e *InvalidSaveNameError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type SaveNotFoundError struct {
+	name string
+}
+
+func (e *SaveNotFoundError) Error() string {
+	return fmt.Sprintf("there is no save named \"%v\"", e.name)
+}
+
+func (e *SaveNotFoundError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type SaveReadError struct {
+	readFileErr error
+	name        string
+}
+
+func (e *SaveReadError) Error() string {
+	return fmt.Sprintf("could not read save \"%v\": %v", e.name, e.readFileErr)
+}
+
+func (e *SaveReadError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type SaveParsingError struct {
+	unmarshalError error
+	name           string
+}
+
+func (e *SaveParsingError) Error() string {
+	return fmt.Sprintf("could not parse save \"%v\": %v", e.name, e.unmarshalError)
+}
+
+func (e *SaveParsingError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidMoveError struct {
+	move Move
+	name string
+}
+
+func (e *InvalidMoveError) Error() string {
+	return fmt.Sprintf("save \"%v\" has an invalid move \"%v\" at %v", e.name, e.move.Action, e.move.Position)
+}
+
+func (e *InvalidMoveError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type SaveWriteError struct {
+	writeFileErr error
+	name         string
+}
+
+func (e *SaveWriteError) Error() string {
+	return fmt.Sprintf("could not write save \"%v\": do you have the right permissions?", e.name)
+}
+
+func (e *SaveWriteError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+func getPath(name string) (string, error) {
+	if !nameRegex.MatchString(name) {
+		return "", &InvalidSaveNameError{name}
+	}
+	return filepath.Join(paths.SavesPath, name+extension), nil
+}
+
+// Overwrites the save with the same name
+func Save(name string, game Game) error {
+	path, err := getPath(name)
+	if err != nil {
+		return err
+	}
+
+	gameBin, err := json.MarshalIndent(game, "", "  ")
+	if err != nil {
+		return &SaveWriteError{err, name}
+	}
+	if err = os.MkdirAll(paths.SavesPath, 0777); err != nil {
+		return &SaveWriteError{err, name}
+	}
+	if err = os.WriteFile(path, gameBin, 0666); err != nil {
+		return &SaveWriteError{err, name}
+	}
+
+	return nil
+}
+
+func Load(name string) (Game, error) {
+	path, err := getPath(name)
+	if err != nil {
+		return Game{}, err
+	}
+
+	gameBin, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Game{}, &SaveNotFoundError{name}
+	}
+	if err != nil {
+		return Game{}, &SaveReadError{err, name}
+	}
+
+	var game Game
+	if err = json.Unmarshal(gameBin, &game); err != nil {
+		return Game{}, &SaveParsingError{err, name}
+	}
+
+	// The saves could be edited by hand so only the changes on the field are taken from them
+	for _, move := range game.Moves {
+		if !actions.IsAction(string(move.Action)) || !move.Action.IsChange() ||
+			move.Position.X >= game.Width || move.Position.Y >= game.Height {
+			return Game{}, &InvalidMoveError{move, name}
+		}
+	}
+
+	return game, nil
+}
+
+func Delete(name string) error {
+	path, err := getPath(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &SaveNotFoundError{name}
+	}
+	if err != nil {
+		return &SaveWriteError{err, name}
+	}
+	return nil
+}
+
+func Exists(name string) bool {
+	return slices.Contains(List(), name)
+}
+
+// Returns the names of the saves in the alphabetical order
+// There are no saves until the first one is made
+func List() []string {
+	entries, err := os.ReadDir(paths.SavesPath)
+	if err != nil {
+		return []string{}
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), extension)
+		if ok && !entry.IsDir() && nameRegex.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
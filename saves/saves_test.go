@@ -0,0 +1,110 @@
+package saves
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	actions "sweep/shared/consts/actions"
+	types "sweep/shared/types"
+	paths "sweep/shared/vars/paths"
+)
+
+func Test_SaveLoad(t *testing.T) {
+	paths.SavesPath = t.TempDir()
+
+	if names := List(); len(names) != 0 {
+		t.Errorf("[Assertion failed] expected no saves, got %v", names)
+	}
+
+	game := Game{
+		Width:    9,
+		Height:   9,
+		Mines:    10,
+		Seed:     42,
+		Moves:    []Move{{Action: actions.OpenTile, Position: types.Position{X: 4, Y: 4}}},
+		Duration: 3 * time.Second,
+	}
+	for _, name := range []string{"quick", "b-2"} {
+		if err := Save(name, game); err != nil {
+			t.Fatalf("[Assertion failed] could not save %v: %v", name, err)
+		}
+	}
+
+	loaded, err := Load("quick")
+	if err != nil {
+		t.Fatalf("[Assertion failed] could not load: %v", err)
+	}
+	if loaded.Seed != game.Seed || !slices.Equal(loaded.Moves, game.Moves) || loaded.Duration != game.Duration {
+		t.Errorf("[Assertion failed] expected %v, got %v", game, loaded)
+	}
+
+	if names := List(); !slices.Equal(names, []string{"b-2", "quick"}) {
+		t.Errorf("[Assertion failed] expected the saves to be listed, got %v", names)
+	}
+}
+
+func Test_LoadErrors(t *testing.T) {
+	paths.SavesPath = t.TempDir()
+
+	type TestCase struct {
+		name     string
+		expected error
+	}
+
+	testCases := []TestCase{
+		{name: "missing", expected: &SaveNotFoundError{"missing"}},
+		{name: "../config", expected: &InvalidSaveNameError{"../config"}},
+		{name: "", expected: &InvalidSaveNameError{""}},
+	}
+
+	for n, testCase := range testCases {
+		_, err := Load(testCase.name)
+		if !errors.Is(err, testCase.expected) {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v, actual: %v", n+1, testCase.expected, err)
+		}
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	paths.SavesPath = t.TempDir()
+
+	if err := Save(LastGame, Game{Width: 9, Height: 9, Mines: 10}); err != nil {
+		t.Fatalf("[Assertion failed] could not save: %v", err)
+	}
+	if !Exists(LastGame) {
+		t.Errorf("[Assertion failed] expected %v to exist", LastGame)
+	}
+	if err := Delete(LastGame); err != nil {
+		t.Errorf("[Assertion failed] could not delete: %v", err)
+	}
+	if Exists(LastGame) {
+		t.Errorf("[Assertion failed] expected %v to be deleted", LastGame)
+	}
+	if err := Delete(LastGame); !errors.Is(err, &SaveNotFoundError{LastGame}) {
+		t.Errorf("[Assertion failed] expected: %v, actual: %v", &SaveNotFoundError{LastGame}, err)
+	}
+}
+
+func Test_LoadInvalidMoves(t *testing.T) {
+	paths.SavesPath = t.TempDir()
+
+	testCases := []Move{
+		{Action: actions.Quit, Position: types.Position{X: 4, Y: 4}},
+		{Action: "open tiel", Position: types.Position{X: 4, Y: 4}},
+		{Action: actions.MoveCursorDown, Position: types.Position{X: 4, Y: 4}},
+		{Action: actions.OpenTile, Position: types.Position{X: 9, Y: 4}},
+	}
+
+	for n, move := range testCases {
+		game := Game{Width: 9, Height: 9, Mines: 10, Moves: []Move{{Action: actions.OpenTile}, move}}
+		if err := Save("edited", game); err != nil {
+			t.Fatalf("[Assertion failed] could not save: %v", err)
+		}
+		expected := &InvalidMoveError{move, "edited"}
+		if _, err := Load("edited"); !errors.Is(err, expected) {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v, actual: %v", n+1, expected, err)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package actions
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
+
+	regexes "sweep/shared/vars/regexes"
 )
 
 type ActionType string
@@ -16,35 +19,231 @@ const (
 	MoveCursorRight ActionType = "move cursor right"
 	OpenTile        ActionType = "open tile"
 	FlagTile        ActionType = "flag tile"
+	ChordTile       ActionType = "chord tile"
 
 	MoveCursorToTopRow      ActionType = "move cursor to top row"
 	MoveCursorToBottomRow   ActionType = "move cursor to bottom row"
 	MoveCursorToFirstColumn ActionType = "move cursor to first column"
 	MoveCursorToLastColumn  ActionType = "move cursor to last column"
+
+	MoveCursorToNextClosedTile          ActionType = "move cursor to next closed tile"
+	MoveCursorToPreviousClosedTile      ActionType = "move cursor to previous closed tile"
+	MoveCursorToNextFrontierTile        ActionType = "move cursor to next frontier tile"
+	MoveCursorToPreviousFrontierTile    ActionType = "move cursor to previous frontier tile"
+	MoveCursorToNextUnsatisfiedTile     ActionType = "move cursor to next unsatisfied tile"
+	MoveCursorToPreviousUnsatisfiedTile ActionType = "move cursor to previous unsatisfied tile"
+
+	ScrollUp     ActionType = "scroll up"
+	ScrollDown   ActionType = "scroll down"
+	ScrollLeft   ActionType = "scroll left"
+	ScrollRight  ActionType = "scroll right"
+	CenterCursor ActionType = "center cursor"
+
+	CycleDensity  ActionType = "cycle density"
+	ToggleMinimap ActionType = "toggle minimap"
+
+	SetMark     ActionType = "set mark"
+	JumpToMark  ActionType = "jump to mark"
+	JumpBack    ActionType = "jump back"
+	JumpForward ActionType = "jump forward"
+
+	RecordMacro  ActionType = "record macro"
+	PlayMacro    ActionType = "play macro"
+	RepeatAction ActionType = "repeat action"
+
+	CommandLine ActionType = "command line"
+	Help        ActionType = "help"
+
+	Pause ActionType = "pause"
+
+	Quit             ActionType = "quit"
+	RestartSameBoard ActionType = "restart same board"
+	NewGame          ActionType = "new game"
+	MainMenu         ActionType = "main menu"
+	ClearKeyBuffer   ActionType = "clear key buffer"
+)
+
+var allActions = []ActionType{
+	MoveCursorUp, MoveCursorDown, MoveCursorLeft, MoveCursorRight,
+	OpenTile, FlagTile, ChordTile,
+	MoveCursorToTopRow, MoveCursorToBottomRow, MoveCursorToFirstColumn, MoveCursorToLastColumn,
+	MoveCursorToNextClosedTile, MoveCursorToPreviousClosedTile,
+	MoveCursorToNextFrontierTile, MoveCursorToPreviousFrontierTile,
+	MoveCursorToNextUnsatisfiedTile, MoveCursorToPreviousUnsatisfiedTile,
+	ScrollUp, ScrollDown, ScrollLeft, ScrollRight, CenterCursor,
+	CycleDensity, ToggleMinimap,
+	SetMark, JumpToMark, JumpBack, JumpForward,
+	RecordMacro, PlayMacro, RepeatAction,
+	CommandLine, Help, Pause,
+	Quit, RestartSameBoard, NewGame, MainMenu, ClearKeyBuffer,
+}
+
+// Lists every action whether it is bound or not
+func GetActions() []ActionType {
+	return slices.Clone(allActions)
+}
+
+// Screens the keys are bound on, the same key may be bound to a different action on every one of them
+type Context string
+
+const (
+	GameContext        Context = "game"
+	StartScreenContext Context = "start screen"
+	EndScreenContext   Context = "end screen"
+	// The main menu and the screens opened from it: stats, settings and replays
+	MenuContext Context = "menu"
 )
 
+var contexts = []Context{GameContext, StartScreenContext, EndScreenContext, MenuContext}
+
+// Lists every context in the order the keymaps are shown in
+func GetContexts() []Context {
+	return slices.Clone(contexts)
+}
+
+// Global actions leave the screen or reset it, they used to be taken with keys fixed in every screen
+func (a ActionType) IsGlobal() bool {
+	switch a {
+	case Quit, RestartSameBoard, NewGame, MainMenu, ClearKeyBuffer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Screens the action is taken on
+// The start screen and the menus move between their items with the keys moving the cursor in the game
+// and go back to the main menu with the keys clearing the typed ones as well
+func (a ActionType) GetContexts() []Context {
+	switch a {
+	case Quit, MainMenu:
+		return []Context{GameContext, StartScreenContext, EndScreenContext, MenuContext}
+	case RestartSameBoard, NewGame:
+		return []Context{GameContext, EndScreenContext}
+	case ClearKeyBuffer, MoveCursorUp, MoveCursorDown:
+		return []Context{GameContext, StartScreenContext, MenuContext}
+	default:
+		return []Context{GameContext}
+	}
+}
+
+// Tells if the action is taken on the screen
+func (a ActionType) IsIn(context Context) bool {
+	return slices.Contains(a.GetContexts(), context)
+}
+
+// The game takes key sequences with quantifiers and registers
 var bindingsMap map[string]ActionType = map[string]ActionType{}
 
+// Mouse buttons are kept apart so they are never mistaken for the start of a key sequence
+var mouseBindingsMap map[string]ActionType = map[string]ActionType{}
+
+// The other screens take single keys as they are
+var screenBindingsMap = map[Context]map[string]ActionType{
+	StartScreenContext: {},
+	EndScreenContext:   {},
+	MenuContext:        {},
+}
+
+// A key bound both to a global action and to an action of the screen is taken by the latter
+// so the keys of the global actions are only the fallback like "q" quitting where it does not record macros
+func setBinding(bindings map[string]ActionType, binding string, a ActionType) {
+	if bound, ok := bindings[binding]; ok && !bound.IsGlobal() && a.IsGlobal() {
+		return
+	}
+	bindings[binding] = a
+}
+
 func (a ActionType) SetBinding(binding string) {
-	bindingsMap[binding] = a
+	if regexes.MouseButtonRegex.MatchString(binding) {
+		mouseBindingsMap[binding] = a
+		return
+	}
+	for _, context := range a.GetContexts() {
+		if context == GameContext {
+			setBinding(bindingsMap, binding, a)
+		} else {
+			setBinding(screenBindingsMap[context], binding, a)
+		}
+	}
+}
+
+// Takes a single key pressed on a screen other than the game
+func GetScreenAction(context Context, key string) (ActionType, bool) {
+	action, ok := screenBindingsMap[context][key]
+	return action, ok
+}
+
+// Lists the keys bound to the action on a screen other than the game sorted by their keys
+func GetScreenKeys(context Context, kind ActionType) []string {
+	var keys []string
+	for key, bound := range screenBindingsMap[context] {
+		if bound == kind {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Takes the mouse event as a string like "left press" or "ctrl+right press"
+func GetMouseAction(button string) (ActionType, bool) {
+	action, ok := mouseBindingsMap[button]
+	return action, ok
 }
 
 func IsAction(str string) bool {
-	switch ActionType(str) {
+	return slices.Contains(allActions, ActionType(str))
+}
+
+// Actions that take the key pressed after the binding as their argument like VIM marks
+func (a ActionType) TakesRegister() bool {
+	switch a {
+	case SetMark, JumpToMark, RecordMacro, PlayMacro:
+		return true
+	default:
+		return false
+	}
+}
+
+// Actions repeated as many times as the quantifier typed before their binding
+func (a ActionType) IsQuantifiable() bool {
+	switch a {
 	case MoveCursorDown, MoveCursorLeft,
 		MoveCursorRight, MoveCursorUp,
-		OpenTile, FlagTile,
-		MoveCursorToBottomRow, MoveCursorToFirstColumn,
-		MoveCursorToLastColumn, MoveCursorToTopRow:
+		MoveCursorToBottomRow,
+		MoveCursorToTopRow, MoveCursorToLastColumn,
+		MoveCursorToNextClosedTile, MoveCursorToPreviousClosedTile,
+		MoveCursorToNextFrontierTile, MoveCursorToPreviousFrontierTile,
+		MoveCursorToNextUnsatisfiedTile, MoveCursorToPreviousUnsatisfiedTile,
+		ScrollUp, ScrollDown, ScrollLeft, ScrollRight,
+		PlayMacro, RepeatAction:
+		return true
+	default:
+		return false
+	}
+}
+
+// Actions changing the field are the ones repeated with the dot like the changes in VIM and kept in the saves
+func (a ActionType) IsChange() bool {
+	switch a {
+	case OpenTile, FlagTile, ChordTile:
 		return true
 	default:
 		return false
 	}
 }
 
+// Registers are named with a lowercase letter
+func IsRegister(str string) bool {
+	return len(str) == 1 && str[0] >= 'a' && str[0] <= 'z'
+}
+
 type Action struct {
 	Kind       ActionType
 	Quantifier uint16
+	// Set only for the actions that take a register
+	Register rune
 }
 
 type QuantifierParseError struct {
@@ -58,6 +257,17 @@ func (e *QuantifierParseError) Is(target error) bool {
 	return e.Error() == target.Error()
 }
 
+type MissingRegisterError struct {
+	bind string
+}
+
+func (e *MissingRegisterError) Error() string {
+	return fmt.Sprintf("bind \"%v\" has to be followed by a register", e.bind)
+}
+func (e *MissingRegisterError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
 type InvalidBindError struct {
 	bind string
 }
@@ -69,7 +279,11 @@ func (e *InvalidBindError) Is(target error) bool {
 	return e.Error() == target.Error()
 }
 
+// Like in VIM a quantifier never starts with 0 so the 0 could be bound to an action
 func getKeysFromKeyStrokes(keyStrokes string) string {
+	if strings.HasPrefix(keyStrokes, "0") {
+		return keyStrokes
+	}
 	lastDigitIx := -1
 
 	symbols := strings.Split(keyStrokes, "")
@@ -107,17 +321,135 @@ func getQuantifierFromKeyStrokes(keyStrokes string, keys string) (uint16, error)
 	return quantifier, nil
 }
 
+// Splits the key strokes into the binding of an action taking a register and the register itself
+func getRegisterAction(keyStrokes string) (*Action, bool) {
+	keys := getKeysFromKeyStrokes(keyStrokes)
+	if len(keys) < 2 {
+		return nil, false
+	}
+	binding, register := keys[:len(keys)-1], keys[len(keys)-1:]
+	kind, ok := bindingsMap[binding]
+	if !ok || !kind.TakesRegister() || !IsRegister(register) {
+		return nil, false
+	}
+	quantifier, err := getQuantifierFromKeyStrokes(keyStrokes, keys)
+	if err != nil {
+		return nil, false
+	}
+	return &Action{
+		Kind:       kind,
+		Quantifier: quantifier,
+		Register:   rune(register[0]),
+	}, true
+}
+
+// Takes the key strokes as they are, without a quantifier or a register
+func GetBoundAction(keyStrokes string) (ActionType, bool) {
+	bound, ok := bindingsMap[keyStrokes]
+	return bound, ok
+}
+
+// Tells if the key strokes are a binding of the action as they are, without a quantifier or a register
+func IsBinding(keyStrokes string, kind ActionType) bool {
+	bound, ok := GetBoundAction(keyStrokes)
+	return ok && bound == kind
+}
+
+// Splits a binding into the keys pressed one after another
+// Keys with modifiers and the named keys like "ctrl+e" or "enter" are a single key each
+func SplitKeys(binding string) []string {
+	var keys []string
+	for binding != "" {
+		key := regexes.KeyRegex.FindString(binding)
+		keys = append(keys, key)
+		binding = binding[len(key):]
+	}
+	return keys
+}
+
+// Tells if the binding starts with the same keys, so "a" is not the start of "alt+h"
+func IsKeysPrefix(prefix, binding string) bool {
+	prefixKeys := SplitKeys(prefix)
+	bindingKeys := SplitKeys(binding)
+	return len(prefixKeys) <= len(bindingKeys) && slices.Equal(prefixKeys, bindingKeys[:len(prefixKeys)])
+}
+
+// Tells if a longer binding starts with the key strokes so they may be the start of it rather than an action on their own
+func HasLongerBinding(keyStrokes string) bool {
+	keys := getKeysFromKeyStrokes(keyStrokes)
+	for keyPress := range bindingsMap {
+		if keyPress != keys && IsKeysPrefix(keys, keyPress) {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys or a mouse button bound to an action as they are resolved
+type Binding struct {
+	// The whole binding, ending with {a-z} for the actions taking a register
+	Keys string
+	Kind ActionType
+}
+
+// Lists the bindings the key strokes are the start of, sorted by their keys
+// Only the quantifiable actions are listed once a quantifier is typed
+func GetCompletions(keyStrokes string) []Binding {
+	keys := getKeysFromKeyStrokes(keyStrokes)
+	isQuantified := keys != keyStrokes
+
+	var completions []Binding
+	for keyPress, kind := range bindingsMap {
+		if !IsKeysPrefix(keys, keyPress) || (isQuantified && !kind.IsQuantifiable()) {
+			continue
+		}
+		if kind.TakesRegister() {
+			keyPress += "{a-z}"
+		}
+		completions = append(completions, Binding{keyPress, kind})
+	}
+	sortBindings(completions)
+	return completions
+}
+
+// Lists every binding of the screen sorted by their keys
+func GetScreenKeymap(context Context) []Binding {
+	if context == GameContext {
+		return GetKeymap()
+	}
+	var keymap []Binding
+	for key, kind := range screenBindingsMap[context] {
+		keymap = append(keymap, Binding{key, kind})
+	}
+	sortBindings(keymap)
+	return keymap
+}
+
+// Lists every binding of the keys and the mouse in the game sorted by their keys
+func GetKeymap() []Binding {
+	keymap := GetCompletions("")
+	for button, kind := range mouseBindingsMap {
+		keymap = append(keymap, Binding{button, kind})
+	}
+	sortBindings(keymap)
+	return keymap
+}
+
+func sortBindings(bindings []Binding) {
+	slices.SortFunc(bindings, func(a, b Binding) int {
+		return strings.Compare(a.Keys, b.Keys)
+	})
+}
+
 func AnyBindingStartWith(keyStrokes string) bool {
+	if _, ok := getRegisterAction(keyStrokes); ok {
+		return true
+	}
 	for keyPress, actionType := range bindingsMap {
-		if strings.HasPrefix(keyPress, keyStrokes) {
+		if IsKeysPrefix(keyStrokes, keyPress) {
 			return true
 		}
-		switch actionType {
-		case MoveCursorDown, MoveCursorLeft,
-			MoveCursorRight, MoveCursorUp,
-			MoveCursorToBottomRow,
-			MoveCursorToTopRow, MoveCursorToLastColumn:
-
+		if actionType.IsQuantifiable() {
 			keys := getKeysFromKeyStrokes(keyStrokes)
 			quantifier, err := getQuantifierFromKeyStrokes(keyStrokes, keys)
 			if err != nil {
@@ -132,7 +464,7 @@ func AnyBindingStartWith(keyStrokes string) bool {
 				return true
 			}
 
-			if strings.HasPrefix(keyPress, keys) {
+			if IsKeysPrefix(keys, keyPress) {
 				return true
 			}
 		}
@@ -142,7 +474,14 @@ func AnyBindingStartWith(keyStrokes string) bool {
 }
 
 func GetAction(keyStrokes string) (*Action, error) {
+	if action, ok := getRegisterAction(keyStrokes); ok {
+		return action, nil
+	}
+
 	kind, ok := bindingsMap[keyStrokes]
+	if ok && kind.TakesRegister() {
+		return nil, &MissingRegisterError{keyStrokes}
+	}
 	if ok {
 		return &Action{
 			Kind:       kind,
@@ -155,6 +494,9 @@ func GetAction(keyStrokes string) (*Action, error) {
 	if !ok {
 		return nil, &InvalidBindError{keys}
 	}
+	if kind.TakesRegister() {
+		return nil, &MissingRegisterError{keys}
+	}
 	quantifier, err := getQuantifierFromKeyStrokes(keyStrokes, keys)
 	if err != nil {
 		return nil, err
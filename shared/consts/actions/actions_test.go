@@ -2,6 +2,7 @@ package actions
 
 import (
 	"errors"
+	"slices"
 	"testing"
 )
 
@@ -142,3 +143,242 @@ func Test_AnyBindingStartWith(t *testing.T) {
 		}
 	}
 }
+
+func Test_GetMouseAction(t *testing.T) {
+	type TestCase struct {
+		button   string
+		expected ActionType
+		ok       bool
+	}
+
+	bindingsMap = map[string]ActionType{}
+	mouseBindingsMap = map[string]ActionType{}
+	OpenTile.SetBinding("left press")
+	ChordTile.SetBinding("shift+middle press")
+
+	testCases := []TestCase{
+		{button: "left press", expected: OpenTile, ok: true},
+		{button: "shift+middle press", expected: ChordTile, ok: true},
+		{button: "right press", ok: false},
+	}
+
+	for n, testCase := range testCases {
+		actual, ok := GetMouseAction(testCase.button)
+		if ok != testCase.ok || actual != testCase.expected {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v %v, actual: %v %v", n+1, testCase.expected, testCase.ok, actual, ok)
+		}
+	}
+
+	// Mouse buttons must not be taken for the start of a key sequence
+	if AnyBindingStartWith("l") {
+		t.Errorf("[Assertion failed] \"l\" should not start any binding")
+	}
+}
+
+func Test_GetRegisterAction(t *testing.T) {
+	type TestCase struct {
+		keyStrokes string
+		kind       ActionType
+		register   rune
+		expected   error
+	}
+
+	bindingsMap = map[string]ActionType{}
+	SetMark.SetBinding("m")
+	JumpToMark.SetBinding("'")
+	MoveCursorDown.SetBinding("j")
+
+	testCases := []TestCase{
+		{keyStrokes: "ma", kind: SetMark, register: 'a'},
+		{keyStrokes: "'z", kind: JumpToMark, register: 'z'},
+		{keyStrokes: "m", expected: &MissingRegisterError{"m"}},
+		{keyStrokes: "mA", expected: &InvalidBindError{"mA"}},
+		{keyStrokes: "j", kind: MoveCursorDown},
+	}
+
+	for n, testCase := range testCases {
+		action, err := GetAction(testCase.keyStrokes)
+		if !errors.Is(err, testCase.expected) {
+			t.Errorf("[Assertion failed] #%v error\nexpected: %v, actual: %v", n+1, testCase.expected, err)
+			continue
+		}
+		if action != nil && (action.Kind != testCase.kind || action.Register != testCase.register) {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v %q, actual: %v %q", n+1, testCase.kind, testCase.register, action.Kind, action.Register)
+		}
+	}
+
+	// The binding alone waits for the register, a register that is not a letter ends the sequence
+	if !AnyBindingStartWith("m") || !AnyBindingStartWith("ma") || AnyBindingStartWith("m1") {
+		t.Errorf("[Assertion failed] \"m\" and \"ma\" should start a binding while \"m1\" should not")
+	}
+}
+
+func Test_MacroActions(t *testing.T) {
+	type TestCase struct {
+		keyStrokes string
+		kind       ActionType
+		quantifier uint16
+		register   rune
+	}
+
+	bindingsMap = map[string]ActionType{}
+	RecordMacro.SetBinding("q")
+	PlayMacro.SetBinding("@")
+	RepeatAction.SetBinding(".")
+
+	testCases := []TestCase{
+		{keyStrokes: "qa", kind: RecordMacro, quantifier: 1, register: 'a'},
+		{keyStrokes: "@a", kind: PlayMacro, quantifier: 1, register: 'a'},
+		{keyStrokes: "12@b", kind: PlayMacro, quantifier: 12, register: 'b'},
+		{keyStrokes: ".", kind: RepeatAction, quantifier: 1},
+		{keyStrokes: "3.", kind: RepeatAction, quantifier: 3},
+	}
+
+	for n, testCase := range testCases {
+		if !AnyBindingStartWith(testCase.keyStrokes[:len(testCase.keyStrokes)-1]) {
+			t.Errorf("[Assertion failed] #%v\n%q should start a binding", n+1, testCase.keyStrokes[:len(testCase.keyStrokes)-1])
+		}
+		action, err := GetAction(testCase.keyStrokes)
+		if err != nil {
+			t.Errorf("[Assertion failed] #%v\nexpected no error, actual: %v", n+1, err)
+			continue
+		}
+		if action.Kind != testCase.kind || action.Quantifier != testCase.quantifier || action.Register != testCase.register {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v %v %q, actual: %v %v %q", n+1, testCase.kind, testCase.quantifier, testCase.register, action.Kind, action.Quantifier, action.Register)
+		}
+	}
+
+	// Recording is stopped by the binding pressed on its own
+	if !IsBinding("q", RecordMacro) || IsBinding("qa", RecordMacro) || IsBinding("@", RecordMacro) {
+		t.Errorf("[Assertion failed] only \"q\" should be the binding of %v", RecordMacro)
+	}
+}
+
+func Test_GetCompletions(t *testing.T) {
+	type TestCase struct {
+		keyStrokes string
+		expected   []Binding
+	}
+
+	bindingsMap = map[string]ActionType{}
+	MoveCursorToTopRow.SetBinding("gg")
+	OpenTile.SetBinding("g")
+	CenterCursor.SetBinding("gc")
+	SetMark.SetBinding("m")
+	MoveCursorDown.SetBinding("j")
+
+	testCases := []TestCase{
+		{
+			keyStrokes: "g",
+			expected:   []Binding{{"g", OpenTile}, {"gc", CenterCursor}, {"gg", MoveCursorToTopRow}},
+		},
+		{
+			keyStrokes: "3g",
+			expected:   []Binding{{"gg", MoveCursorToTopRow}},
+		},
+		{
+			keyStrokes: "m",
+			expected:   []Binding{{"m{a-z}", SetMark}},
+		},
+		{
+			keyStrokes: "x",
+			expected:   nil,
+		},
+	}
+
+	for n, testCase := range testCases {
+		actual := GetCompletions(testCase.keyStrokes)
+		if !slices.Equal(actual, testCase.expected) {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v, actual: %v", n+1, testCase.expected, actual)
+		}
+	}
+
+	// "g" is an action on its own but may be the start of "gg" as well
+	if !HasLongerBinding("g") || !HasLongerBinding("2g") || HasLongerBinding("gg") || HasLongerBinding("j") {
+		t.Errorf("[Assertion failed] only \"g\" should be the start of a longer binding")
+	}
+}
+
+func Test_GetKeymap(t *testing.T) {
+	bindingsMap = map[string]ActionType{}
+	mouseBindingsMap = map[string]ActionType{}
+	MoveCursorDown.SetBinding("j")
+	JumpToMark.SetBinding("'")
+	OpenTile.SetBinding("left press")
+
+	expected := []Binding{{"'{a-z}", JumpToMark}, {"j", MoveCursorDown}, {"left press", OpenTile}}
+	if actual := GetKeymap(); !slices.Equal(actual, expected) {
+		t.Errorf("[Assertion failed]\nexpected: %v, actual: %v", expected, actual)
+	}
+}
+
+func Test_SplitKeys(t *testing.T) {
+	type TestCase struct {
+		binding  string
+		expected []string
+	}
+
+	testCases := []TestCase{
+		{binding: "gg", expected: []string{"g", "g"}},
+		{binding: "alt+h", expected: []string{"alt+h"}},
+		{binding: "enter", expected: []string{"enter"}},
+		{binding: "ctrl+wj", expected: []string{"ctrl+w", "j"}},
+		{binding: "alt+ctrl+shift+left", expected: []string{"alt+ctrl+shift+left"}},
+		{binding: "", expected: nil},
+	}
+
+	for n, testCase := range testCases {
+		if actual := SplitKeys(testCase.binding); !slices.Equal(actual, testCase.expected) {
+			t.Errorf("[Assertion failed] #%v\nexpected: %q, actual: %q", n+1, testCase.expected, actual)
+		}
+	}
+
+	if !IsKeysPrefix("g", "gg") || IsKeysPrefix("a", "alt+h") || IsKeysPrefix("e", "enter") || IsKeysPrefix("gg", "g") {
+		t.Errorf("[Assertion failed] only \"g\" should be the start of its binding")
+	}
+}
+
+func Test_Contexts(t *testing.T) {
+	type TestCase struct {
+		context  Context
+		key      string
+		expected ActionType
+		ok       bool
+	}
+
+	bindingsMap = map[string]ActionType{}
+	screenBindingsMap = map[Context]map[string]ActionType{StartScreenContext: {}, EndScreenContext: {}, MenuContext: {}}
+	// The global action is bound first and last so the order of the bindings does not matter
+	Quit.SetBinding("q")
+	RecordMacro.SetBinding("q")
+	NewGame.SetBinding("enter")
+	OpenTile.SetBinding("enter")
+	Quit.SetBinding("ctrl+c")
+	MoveCursorDown.SetBinding("j")
+
+	testCases := []TestCase{
+		{context: StartScreenContext, key: "q", expected: Quit, ok: true},
+		{context: EndScreenContext, key: "q", expected: Quit, ok: true},
+		{context: EndScreenContext, key: "enter", expected: NewGame, ok: true},
+		{context: StartScreenContext, key: "enter", ok: false},
+		{context: StartScreenContext, key: "j", expected: MoveCursorDown, ok: true},
+		{context: EndScreenContext, key: "j", ok: false},
+		{context: MenuContext, key: "j", expected: MoveCursorDown, ok: true},
+		{context: MenuContext, key: "enter", ok: false},
+	}
+
+	for n, testCase := range testCases {
+		actual, ok := GetScreenAction(testCase.context, testCase.key)
+		if ok != testCase.ok || actual != testCase.expected {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v %v, actual: %v %v", n+1, testCase.expected, testCase.ok, actual, ok)
+		}
+	}
+
+	// Actions of the game take the keys over from the global ones
+	if !IsBinding("q", RecordMacro) || !IsBinding("enter", OpenTile) || !IsBinding("ctrl+c", Quit) {
+		t.Errorf("[Assertion failed] the game should keep its own actions on the keys of the global ones")
+	}
+	if keys := GetScreenKeys(EndScreenContext, Quit); !slices.Equal(keys, []string{"ctrl+c", "q"}) {
+		t.Errorf("[Assertion failed]\nexpected: [ctrl+c q], actual: %v", keys)
+	}
+}
@@ -0,0 +1,33 @@
+package densities
+
+type Density string
+
+const (
+	// A tile takes a single cell, the cursor is shown by reversing the colors
+	Compact Density = "compact"
+	// A tile takes three cells, the content with a cursor half on each side
+	Normal Density = "normal"
+	// A tile takes five cells on three lines
+	Large Density = "large"
+)
+
+func IsDensity(str string) bool {
+	switch Density(str) {
+	case Compact, Normal, Large:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cycles through the densities from the smallest to the largest
+func Next(density Density) Density {
+	switch density {
+	case Compact:
+		return Normal
+	case Normal:
+		return Large
+	default:
+		return Compact
+	}
+}
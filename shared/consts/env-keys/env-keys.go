@@ -7,4 +7,27 @@ const (
 	Height  string = consts.AppName + "_field_height"
 	Width   string = consts.AppName + "_field_width"
 	Mines   string = consts.AppName + "_mine_count"
+	Lives   string = consts.AppName + "_lives"
+	Players string = consts.AppName + "_players"
+
+	TimeLimit string = consts.AppName + "_time_limit"
+	TimeBonus string = consts.AppName + "_time_bonus"
+
+	WinCondition string = consts.AppName + "_win_condition"
+	NoFlag       string = consts.AppName + "_no_flag"
+	Seed         string = consts.AppName + "_seed"
+
+	RaceHost string = consts.AppName + "_race_host"
+	RaceJoin string = consts.AppName + "_race_join"
+	CoopHost string = consts.AppName + "_coop_host"
+	CoopJoin string = consts.AppName + "_coop_join"
+
+	ServeSSH  string = consts.AppName + "_serve_ssh"
+	ServeHTTP string = consts.AppName + "_serve_http"
+	Headless  string = consts.AppName + "_headless"
+
+	Bench      string = consts.AppName + "_bench"
+	BenchGames string = consts.AppName + "_bench_games"
+
+	CheckBindings string = consts.AppName + "_check_bindings"
 )
@@ -7,8 +7,28 @@ const (
 ╚════██║██║███╗██║██╔══╝  ██╔══╝  ██╔═══╝ 
 ███████║╚███╔███╔╝███████╗███████╗██║     
 ╚══════╝ ╚══╝╚══╝ ╚══════╝╚══════╝╚═╝`
-	AppName     = "sweep"
-	HelpMessage = "Usage " + AppName + ` [OPTION] ...
+	AppName            = "sweep"
+	DefaultHostAddress = ":7777"
+	HelpMessage        = "Usage " + AppName + ` [COMMAND] [OPTION] ...
+The keys of the game are listed by pressing ? in it
+
+List of commands:
+  host[ ADDR]               host a race on the same field for players on 
+                              the network, listens on :7777 by default
+  join ADDR                 join a race hosted at the address
+  coop-host[ ADDR]          host a co-op game where everyone plays on 
+                              one shared field, listens on :7777 by default
+  coop-join ADDR            join a co-op game hosted at the address
+  serve --ssh ADDR          serve the game over SSH, every connection 
+                              plays in its own session
+  serve --http ADDR         serve the JSON API with games played 
+                              over HTTP, both servers can be run at once
+  bench --games[ uint16]    play games with the built-in bot and report 
+                              the win rate, guesses and timing, 1000 by default
+  config check-bindings     print the keymap the bindings resolve to with 
+                              the keys bound twice and the ones in the way 
+                              of each other or of the quantifiers
+
 List of options:
   --help                  display help and exit
   --D, --default-config   copy the default configuration file to the 
@@ -22,6 +42,12 @@ List of options:
   --F, --fill               vary how the color affect the tiles. If the option 
                               is provided, then the colors will fill the background of 
                               the tiles, otherwise they will fill the foreground
+  --S, --strict             require every mine to be flagged to win
+                              on top of opening every safe tile
+  --N, --no-flag            disable the flag tile action entirely 
+                              for no flag (NF) play
+  --headless                play over a JSON lines protocol on stdin and 
+                              stdout instead of the terminal interface
 
   --M, --mines[ uint16]     sets the desired amount of mines to the field
                               if other field arguments are set
@@ -29,5 +55,17 @@ List of options:
                               if other field arguments are set
   --H, --height[ uint16]    sets the desired field height in rows 
                               if other field arguments are set
+  --L, --lives[ uint16]     sets the amount of mines that can be hit 
+                              before the game is lost
+  --U, --players[ uint16]   sets the amount of players (up to 4) taking turns 
+                              on the same field
+  --preset NAME             sets the field to one of the presets:
+                              beginner, intermediate or expert
+  --R, --seed[ int64]       sets the seed for mine generation so the same 
+                              field can be played again
+  --T, --time-limit[ uint16]  sets the time limit in seconds, 
+                                the game is lost when the clock reaches zero
+  --B, --time-bonus[ uint16]  sets the amount of seconds added to the clock 
+                                for each opened safe tile if the time limit is set
 `
 )
@@ -0,0 +1,34 @@
+package presets
+
+import (
+	"maps"
+	"slices"
+)
+
+type Preset struct {
+	Width  uint16
+	Height uint16
+	Mines  uint16
+}
+
+// Field sizes of the classic difficulty levels
+var presets = map[string]Preset{
+	"beginner":     {Width: 9, Height: 9, Mines: 10},
+	"intermediate": {Width: 16, Height: 16, Mines: 40},
+	"expert":       {Width: 30, Height: 16, Mines: 99},
+}
+
+func GetPreset(name string) (Preset, bool) {
+	preset, ok := presets[name]
+	return preset, ok
+}
+
+func IsPreset(name string) bool {
+	_, ok := presets[name]
+	return ok
+}
+
+// Returns the names of the presets in the alphabetical order
+func GetPresetNames() []string {
+	return slices.Sorted(maps.Keys(presets))
+}
@@ -84,6 +84,27 @@ func (tc TileContent) String() string {
 	}
 }
 
+// Every tile content in the order of the numbers first
+var All = []TileContent{Zero, One, Two, Three, Four, Five, Six, Seven, Eight, Mine, Flag, WrongFlag, Empty}
+
+// Glyphs shown with the ascii option instead of the ones of the config
+func (tc TileContent) ASCII() string {
+	switch tc {
+	case Zero:
+		return "x"
+	case Mine:
+		return "M"
+	case Flag:
+		return "F"
+	case WrongFlag:
+		return "W"
+	case Empty:
+		return " "
+	default:
+		return fmt.Sprint(int(tc))
+	}
+}
+
 func FromString(str string) (TileContent, error) {
 	switch str {
 	case zeroString, Zero.String():
@@ -0,0 +1,19 @@
+package winconditions
+
+type WinCondition string
+
+const (
+	// The game is won as soon as every safe tile is open
+	Classic WinCondition = "classic"
+	// The game is won when every safe tile is open and every mine is flagged
+	Strict WinCondition = "strict"
+)
+
+func IsWinCondition(str string) bool {
+	switch WinCondition(str) {
+	case Classic, Strict:
+		return true
+	default:
+		return false
+	}
+}
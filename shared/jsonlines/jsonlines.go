@@ -0,0 +1,62 @@
+package jsonlines
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+)
+
+// Conn sends and receives JSON values one per line
+// Sending is safe for concurrent use, receiving is not
+type Conn struct {
+	mu      sync.Mutex
+	conn    net.Conn
+	encoder *json.Encoder
+	scanner *bufio.Scanner
+}
+
+func NewConn(conn net.Conn) *Conn {
+	scanner := bufio.NewScanner(conn)
+	// Whole fields are sent in a single line
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	return &Conn{
+		conn:    conn,
+		encoder: json.NewEncoder(conn),
+		scanner: scanner,
+	}
+}
+
+func (c *Conn) Send(value any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.encoder.Encode(value)
+}
+
+// Blocks until the next line is received and decodes it into value
+func (c *Conn) Receive(value any) error {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return err
+		}
+		return net.ErrClosed
+	}
+	return json.Unmarshal(c.scanner.Bytes(), value)
+}
+
+// Calls handle for every received value until the connection is closed
+// Lines that could not be decoded are skipped
+func Listen[T any](c *Conn, handle func(T)) {
+	for c.scanner.Scan() {
+		var value T
+		if err := json.Unmarshal(c.scanner.Bytes(), &value); err != nil {
+			continue
+		}
+		handle(value)
+	}
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
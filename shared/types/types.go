@@ -1,5 +1,10 @@
 package types
 
+import (
+	tilecontent "sweep/shared/consts/tile-content"
+	winconditions "sweep/shared/consts/win-conditions"
+)
+
 type Tile byte
 
 type Position struct {
@@ -15,12 +20,22 @@ type IGameEngine interface {
 	FlagToggleTile(Position)
 	OpenTile(Position)
 	GetTile(Position) Tile
+	Reveal(Position) []Position
+	GetTileContent(Position) tilecontent.TileContent
 	IsFinished() bool
+	IsWon() bool
+	Forfeit()
+	GetOpenCount() uint16
 	GetField() [][]Tile
 	SetFieldSize(uint16, uint16) error
 	SetMineCount(uint16) error
 	SetMines(Position)
+	SetSeed(int64)
 	CountNeighbouringMines(Position) byte
+	SetLives(uint16) error
+	SetWinCondition(winconditions.WinCondition)
+	GetLives() uint16
+	GetExplosions() []Position
 
 	GetWidth() uint16
 	GetHeight() uint16
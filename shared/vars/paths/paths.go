@@ -15,12 +15,19 @@ const (
 	configName        = "config.json"
 	configSchemaName  = "config.schema.json"
 	defaultConfigName = "config.default.json"
+	historyName       = "history.json"
+	hostKeyName       = "ssh_host_ed25519"
+	savesName         = "saves"
 )
 
 var (
 	ConfigPath        string
 	ConfigSchemaPath  string
 	DefaultConfigPath string
+	HistoryPath       string
+	HostKeyPath       string
+	// Directory with the saved games
+	SavesPath string
 )
 
 func init() {
@@ -41,4 +48,7 @@ func init() {
 	ConfigPath = basePath + configName
 	ConfigSchemaPath = basePath + configSchemaName
 	DefaultConfigPath = basePath + defaultConfigName
+	HistoryPath = basePath + historyName
+	HostKeyPath = basePath + hostKeyName
+	SavesPath = basePath + savesName
 }
@@ -0,0 +1,73 @@
+package sshserver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	config "sweep/config"
+	paths "sweep/shared/vars/paths"
+	app "sweep/tui/app"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+	log "github.com/charmbracelet/log"
+	ssh "github.com/charmbracelet/ssh"
+	wish "github.com/charmbracelet/wish"
+	activeterm "github.com/charmbracelet/wish/activeterm"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	logging "github.com/charmbracelet/wish/logging"
+	termenv "github.com/muesli/termenv"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// Creates a server where every connection plays in its own session
+// with its own copy of the config
+func CreateServer(address string, conf config.Config) (*ssh.Server, error) {
+	handler := func(ssh.Session) (tea.Model, []tea.ProgramOption) {
+		return app.CreateModel(conf), []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseAllMotion()}
+	}
+
+	return wish.NewServer(
+		wish.WithAddress(address),
+		wish.WithHostKeyPath(paths.HostKeyPath),
+		wish.WithMiddleware(
+			bm.MiddlewareWithColorProfile(handler, termenv.ANSI256),
+			activeterm.Middleware(),
+			logging.Middleware(),
+		),
+	)
+}
+
+// Serves the game over SSH until the process is interrupted
+func Serve(address string, conf config.Config) error {
+	// Styles are shared by every session and should not depend on the terminal of the server
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	server, err := CreateServer(address, conf)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+
+	log.Info("serving over ssh", "address", address)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+			log.Error("could not serve", "error", err)
+			done <- nil
+		}
+	}()
+
+	<-done
+	log.Info("stopping the server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
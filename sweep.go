@@ -1,24 +1,222 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	bench "sweep/bench"
 	config "sweep/config"
+	coop "sweep/coop"
+	headless "sweep/headless"
+	httpserver "sweep/http-server"
+	race "sweep/race"
+	sshserver "sweep/ssh-server"
+	app "sweep/tui/app"
+	cooptui "sweep/tui/coop-tui"
 	gametui "sweep/tui/game-tui"
+	racelobby "sweep/tui/race-lobby"
 	startscreen "sweep/tui/start-screen"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+const defaultBenchGames = 1000
+
+// Players who made no progress for this long are not waited for at the end of the race
+const raceIdleTimeout = 5 * time.Minute
+
 func main() {
 	conf := config.GetConfig()
-	for {
+	if conf.Headless {
+		if err := headless.Run(os.Stdin, os.Stdout, getHeadlessGame(conf)); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if conf.ServeSSH != "" || conf.ServeHTTP != "" {
+		serve(conf)
+		return
+	}
+	if conf.Bench {
+		runBench(conf)
+		return
+	}
+	if conf.RaceHost != "" || conf.RaceJoin != "" {
+		if err := runRace(conf); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if conf.CoopHost != "" || conf.CoopJoin != "" {
+		runCoop(conf)
+		return
+	}
+	// Every screen is shown by the same program, the process ends with it
+	if _, err := tea.NewProgram(app.CreateModel(*conf).WithAutosave(), tea.WithAltScreen(), tea.WithMouseAllMotion()).Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// The peer is closed before the error of the lobby is reported
+func runRace(conf *config.Config) error {
+	var peer race.Peer
+	var host *race.Host
+	var lobby tea.Model
+
+	if conf.RaceHost != "" {
+		if conf.Height == 0 || conf.Mines == 0 || conf.Width == 0 {
+			startScreen := startscreen.CreateModel(conf)
+
+			tea.NewProgram(startScreen, tea.WithAltScreen()).Run()
+		}
+		// The start screen was quit without picking the field
+		if conf.Height == 0 || conf.Mines == 0 || conf.Width == 0 {
+			return nil
+		}
+
+		var err error
+		host, err = race.Listen(conf.RaceHost, race.GetPlayerName())
+		if err != nil {
+			log.Fatal(err)
+		}
+		peer = host
+		lobby = racelobby.CreateHostModel(conf, host)
+	} else {
+		client, err := race.Join(conf.RaceJoin, race.GetPlayerName())
+		if err != nil {
+			log.Fatal(err)
+		}
+		peer = client
+		lobby = racelobby.CreateClientModel(conf, client)
+	}
+	defer peer.Close()
+
+	lobby, err := tea.NewProgram(lobby, tea.WithAltScreen()).Run()
+	if err != nil {
+		return err
+	}
+	if isStarted, err := racelobby.IsStarted(lobby); !isStarted {
+		return err
+	}
+
+	gameModel := gametui.CreateModel(conf).WithRace(peer)
+
+	tea.NewProgram(gameModel, tea.WithAltScreen(), tea.WithMouseAllMotion()).Run()
+
+	// The others still play through the host, so it is only closed once they are done
+	if host != nil {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		fmt.Println("waiting for the other players to finish the race, ctrl+c ends it")
+		host.WaitForPlayers(ctx, raceIdleTimeout)
+	}
+	return nil
+}
+
+func runCoop(conf *config.Config) {
+	address := conf.CoopJoin
+
+	if conf.CoopHost != "" {
 		if conf.Height == 0 || conf.Mines == 0 || conf.Width == 0 {
 			startScreen := startscreen.CreateModel(conf)
 
 			tea.NewProgram(startScreen, tea.WithAltScreen()).Run()
 		}
+		// The start screen was quit without picking the field
+		if conf.Height == 0 || conf.Mines == 0 || conf.Width == 0 {
+			return
+		}
+
+		server, err := coop.Listen(conf.CoopHost, coop.Game{
+			Seed:   time.Now().UnixNano(),
+			Width:  conf.Width,
+			Height: conf.Height,
+			Mines:  conf.Mines,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer server.Close()
+
+		// The host plays as any other client over the loopback
+		_, port, err := net.SplitHostPort(server.GetAddress())
+		if err != nil {
+			log.Fatal(err)
+		}
+		address = net.JoinHostPort("localhost", port)
+	}
 
-		gameModel := gametui.CreateModel(conf)
+	client, err := coop.Join(address, race.GetPlayerName())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	tea.NewProgram(cooptui.CreateModel(client), tea.WithAltScreen()).Run()
+}
+
+func getHeadlessGame(conf *config.Config) headless.Game {
+	return headless.Game{
+		Width:        conf.Width,
+		Height:       conf.Height,
+		Mines:        conf.Mines,
+		Seed:         conf.Seed,
+		Lives:        conf.Lives,
+		WinCondition: conf.WinCondition,
+		NoFlag:       conf.NoFlag,
+	}
+}
+
+// Runs every requested server until the process is interrupted
+func serve(conf *config.Config) {
+	var wg sync.WaitGroup
+	if conf.ServeSSH != "" {
+		wg.Go(func() {
+			if err := sshserver.Serve(conf.ServeSSH, *conf); err != nil {
+				log.Fatal(err)
+			}
+		})
+	}
+	if conf.ServeHTTP != "" {
+		wg.Go(func() {
+			if err := httpserver.Serve(conf.ServeHTTP, getHeadlessGame(conf)); err != nil {
+				log.Fatal(err)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func runBench(conf *config.Config) {
+	if conf.Height == 0 || conf.Mines == 0 || conf.Width == 0 {
+		log.Fatal("the field for the benchmark has to be set with --preset or --W, --H and --M")
+	}
+	games := conf.BenchGames
+	if games == 0 {
+		games = defaultBenchGames
+	}
+	seed := conf.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
 
-		tea.NewProgram(gameModel, tea.WithAltScreen()).Run()
+	fmt.Printf("playing %v games on %vx%v with %v mines\n", games, conf.Width, conf.Height, conf.Mines)
+	result, err := bench.Run(bench.Options{
+		Games:  games,
+		Width:  conf.Width,
+		Height: conf.Height,
+		Mines:  conf.Mines,
+		Seed:   seed,
+	})
+	if err != nil {
+		log.Fatal(err)
 	}
+	fmt.Print(result)
 }
@@ -0,0 +1,205 @@
+package app
+
+import (
+	config "sweep/config"
+	saves "sweep/saves"
+	gametui "sweep/tui/game-tui"
+	menu "sweep/tui/menu"
+	navigation "sweep/tui/navigation"
+	settings "sweep/tui/settings"
+	startscreen "sweep/tui/start-screen"
+	stats "sweep/tui/stats"
+	styles "sweep/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Routes between the screens of a single program
+// Screens ask to be left with the navigation messages and the app picks the next screen for them
+type model struct {
+	config *config.Config
+	screen tea.Model
+	// The game played last, restarting takes its field again
+	game      tea.Model
+	isPlaying bool
+	// Games left unfinished are saved to be continued from the main menu
+	autosave   bool
+	windowSize *tea.WindowSizeMsg
+}
+
+var _ tea.Model = model{}
+
+// The config is copied so every app could pick its own field
+// The game is started at once when the field is set already, otherwise the main menu is shown
+func CreateModel(conf config.Config) model {
+	m := model{config: &conf}
+	if conf.Height == 0 || conf.Mines == 0 || conf.Width == 0 {
+		m.screen = m.createMainMenu("")
+		return m
+	}
+	m.game = gametui.CreateModel(m.config)
+	m.screen = m.game
+	m.isPlaying = true
+	return m
+}
+
+// Saves the unfinished game on leaving it, only one program should do it as there is a single save for it
+func (m model) WithAutosave() model {
+	m.autosave = true
+	if !m.isPlaying {
+		m.screen = m.createMainMenu("")
+	}
+	return m
+}
+
+func (m model) createMainMenu(warning string) tea.Model {
+	mainMenu := menu.CreateMainMenu(m.autosave && saves.Exists(saves.LastGame))
+	if warning != "" {
+		return mainMenu.WithBody(styles.WarningText.Render(warning))
+	}
+	return mainMenu
+}
+
+func (m model) createReplaysMenu() tea.Model {
+	var items []menu.Item
+	for _, name := range saves.List() {
+		items = append(items, menu.Item{Title: name, Cmd: func() tea.Msg { return navigation.ReplayMsg{Name: name} }})
+	}
+	items = append(items, menu.Item{Title: "back", Cmd: navigation.MainMenu})
+
+	replays := menu.CreateModel("replays", items)
+	if len(items) == 1 {
+		return replays.WithBody(styles.DimText.Render("no saves yet, games are saved with :save name"))
+	}
+	return replays
+}
+
+func (m model) createScreen(screen navigation.Screen) tea.Model {
+	switch screen {
+	case navigation.StartScreen:
+		return startscreen.CreateModel(m.config)
+	case navigation.StatsScreen:
+		return stats.CreateModel()
+	case navigation.SettingsScreen:
+		return settings.CreateModel(m.config)
+	case navigation.ReplaysScreen:
+		return m.createReplaysMenu()
+	}
+	return m.createMainMenu("")
+}
+
+// The new screen gets the size of the window the last one got
+func (m model) show(screen tea.Model) (tea.Model, tea.Cmd) {
+	m.screen = screen
+	m.isPlaying = false
+	cmds := []tea.Cmd{tea.ClearScreen, m.screen.Init()}
+	if m.windowSize != nil {
+		windowSize := *m.windowSize
+		cmds = append(cmds, func() tea.Msg { return windowSize })
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) play(game tea.Model) (tea.Model, tea.Cmd) {
+	m.game = game
+	next, cmd := m.show(game)
+	app := next.(model)
+	app.isPlaying = true
+	return app, cmd
+}
+
+// Keeps the game being played so it could be continued after the program is closed
+func (m model) saveGame() error {
+	if !m.autosave || !m.isPlaying {
+		return nil
+	}
+	save, ok := gametui.GetSave(m.game)
+	if !ok {
+		return nil
+	}
+	return saves.Save(saves.LastGame, save)
+}
+
+// The save is removed once it is continued so the same game could not be played twice
+func (m model) continueGame() (tea.Model, tea.Cmd) {
+	save, err := saves.Load(saves.LastGame)
+	if err != nil {
+		return m.show(m.createMainMenu(err.Error()))
+	}
+	game, err := gametui.CreateFromSave(m.config, save)
+	if err != nil {
+		return m.show(m.createMainMenu(err.Error()))
+	}
+	if err = saves.Delete(saves.LastGame); err != nil {
+		return m.show(m.createMainMenu(err.Error()))
+	}
+	return m.play(game)
+}
+
+func (m model) replay(name string) (tea.Model, tea.Cmd) {
+	save, err := saves.Load(name)
+	if err != nil {
+		return m.show(m.createMainMenu(err.Error()))
+	}
+	game, err := gametui.CreateReplay(m.config, save)
+	if err != nil {
+		return m.show(m.createMainMenu(err.Error()))
+	}
+	return m.play(game)
+}
+
+// The game starts its field over itself and keeps the size of the window
+func (m model) restart(msg navigation.RestartMsg) (tea.Model, tea.Cmd) {
+	if m.game == nil {
+		return m.play(gametui.CreateModel(m.config))
+	}
+	var cmd tea.Cmd
+	m.game, cmd = m.game.Update(msg)
+	m.screen = m.game
+	m.isPlaying = true
+	return m, tea.Batch(tea.ClearScreen, cmd)
+}
+
+func (m model) Init() tea.Cmd {
+	return m.screen.Init()
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case navigation.ExitMsg:
+		// The program is closed anyway, the game is lost if it could not be saved
+		m.saveGame()
+		return m, tea.Quit
+	case navigation.MainMenuMsg:
+		if err := m.saveGame(); err != nil {
+			return m.show(m.createMainMenu(err.Error()))
+		}
+		return m.show(m.createMainMenu(""))
+	case navigation.ShowMsg:
+		return m.show(m.createScreen(msg.Screen))
+	case navigation.NewGameMsg:
+		return m.play(gametui.CreateModel(m.config))
+	case navigation.RestartMsg:
+		return m.restart(msg)
+	case navigation.ContinueMsg:
+		return m.continueGame()
+	case navigation.ReplayMsg:
+		return m.replay(msg.Name)
+	case navigation.GameOverMsg:
+		return m.show(msg.EndScreen)
+	case tea.WindowSizeMsg:
+		m.windowSize = &msg
+	}
+
+	var cmd tea.Cmd
+	m.screen, cmd = m.screen.Update(msg)
+	// The game replaces itself when another one is loaded from its command line
+	if m.isPlaying {
+		m.game = m.screen
+	}
+	return m, cmd
+}
+
+func (m model) View() string {
+	return m.screen.View()
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	config "sweep/config"
+	saves "sweep/saves"
+	actions "sweep/shared/consts/actions"
+	types "sweep/shared/types"
+	paths "sweep/shared/vars/paths"
+	navigation "sweep/tui/navigation"
+)
+
+func Test_ContinueGame(t *testing.T) {
+	paths.SavesPath = t.TempDir()
+
+	save := saves.Game{
+		Width:  9,
+		Height: 9,
+		Mines:  10,
+		Seed:   42,
+		Moves:  []saves.Move{{Action: actions.OpenTile, Position: types.Position{X: 4, Y: 4}}},
+		Cursor: types.Position{X: 4, Y: 4},
+	}
+	if err := saves.Save(saves.LastGame, save); err != nil {
+		t.Fatalf("[Assertion failed] could not save the game: %v", err)
+	}
+
+	m := CreateModel(config.Config{}).WithAutosave()
+	next, _ := m.Update(navigation.ContinueMsg{})
+	m = next.(model)
+	if !m.isPlaying {
+		t.Fatalf("[Assertion failed] expected the saved game to be played")
+	}
+	if saves.Exists(saves.LastGame) {
+		t.Errorf("[Assertion failed] expected the save to be removed once it is continued")
+	}
+
+	// Leaving the game saves it again with the moves it was continued with
+	next, _ = m.Update(navigation.MainMenuMsg{})
+	m = next.(model)
+	if m.isPlaying {
+		t.Errorf("[Assertion failed] expected the main menu to be shown")
+	}
+	saved, err := saves.Load(saves.LastGame)
+	if err != nil {
+		t.Fatalf("[Assertion failed] expected the game left to be saved: %v", err)
+	}
+	if saved.Seed != save.Seed || !slices.Equal(saved.Moves, save.Moves) || saved.Cursor != save.Cursor {
+		t.Errorf("[Assertion failed] expected %v, got %v", save, saved)
+	}
+}
+
+func Test_ContinueMissingGame(t *testing.T) {
+	paths.SavesPath = t.TempDir()
+
+	m := CreateModel(config.Config{}).WithAutosave()
+	next, _ := m.Update(navigation.ContinueMsg{})
+	m = next.(model)
+	if m.isPlaying {
+		t.Errorf("[Assertion failed] expected the main menu to be shown when there is nothing to continue")
+	}
+}
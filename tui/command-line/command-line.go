@@ -0,0 +1,194 @@
+package commandline
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	flags "sweep/config/flags"
+	presets "sweep/shared/consts/presets"
+	types "sweep/shared/types"
+)
+
+type CommandName string
+
+const (
+	New    CommandName = "new"
+	Seed   CommandName = "seed"
+	Save   CommandName = "save"
+	Load   CommandName = "load"
+	Goto   CommandName = "goto"
+	Set    CommandName = "set"
+	Preset CommandName = "preset"
+	Quit   CommandName = "q"
+)
+
+// Commands in the order they are completed in
+var commandNames = []CommandName{New, Seed, Save, Load, Goto, Set, Preset, Quit}
+
+var usages = map[CommandName]string{
+	New:    "new [width height mines]",
+	Seed:   "seed number",
+	Save:   "save name",
+	Load:   "load name",
+	Goto:   "goto column row",
+	Set:    "set option [value]",
+	Preset: "preset name",
+	Quit:   "q",
+}
+
+// Options of the set command are the command line flags that make sense during the game
+var setOptions = []string{
+	"ascii", "fill", "height", "lives", "mines", "no-flag",
+	"players", "seed", "strict", "time-bonus", "time-limit", "width",
+}
+
+type Command struct {
+	Name CommandName
+	Args []string
+}
+
+type UnknownCommandError struct {
+	command string
+}
+
+func (e *UnknownCommandError) Error() string {
+	return fmt.Sprintf("not a command: %v", e.command)
+}
+
+func (e *UnknownCommandError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type WrongArgumentCountError struct {
+	command CommandName
+}
+
+func (e *WrongArgumentCountError) Error() string {
+	return fmt.Sprintf("usage: %v", usages[e.command])
+}
+
+func (e *WrongArgumentCountError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidOptionError struct {
+	option string
+}
+
+func (e *InvalidOptionError) Error() string {
+	return fmt.Sprintf("unknown option: %v", e.option)
+}
+
+func (e *InvalidOptionError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+func isArgumentCountValid(name CommandName, count int) bool {
+	switch name {
+	case New:
+		return count == 0 || count == 3
+	case Seed, Save, Load, Preset:
+		return count == 1
+	case Goto:
+		return count == 2
+	case Set:
+		return count == 1 || count == 2
+	default:
+		return count == 0
+	}
+}
+
+func Parse(line string) (Command, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return Command{}, &UnknownCommandError{line}
+	}
+
+	name := CommandName(fields[0])
+	if !slices.Contains(commandNames, name) {
+		return Command{}, &UnknownCommandError{fields[0]}
+	}
+	command := Command{Name: name, Args: fields[1:]}
+	if !isArgumentCountValid(name, len(command.Args)) {
+		return Command{}, &WrongArgumentCountError{name}
+	}
+	if name == Set && !slices.Contains(setOptions, command.Args[0]) {
+		return Command{}, &InvalidOptionError{command.Args[0]}
+	}
+	return command, nil
+}
+
+// Turns the commands that change the options into the command line flags
+// so they are validated and applied the same way as when the game is started
+func (c Command) ToFlags() flags.Flags {
+	switch c.Name {
+	case New:
+		if len(c.Args) == 0 {
+			return flags.Flags{}
+		}
+		return flags.Flags{flags.WIDTH, c.Args[0], flags.HEIGHT, c.Args[1], flags.MINES, c.Args[2]}
+	case Seed:
+		return flags.Flags{flags.SEED, c.Args[0]}
+	case Preset:
+		return flags.Flags{flags.PRESET, c.Args[0]}
+	case Set:
+		return append(flags.Flags{types.Flag("--" + c.Args[0])}, c.Args[1:]...)
+	default:
+		return flags.Flags{}
+	}
+}
+
+// Completes the last word of the line to the longest prefix shared by the candidates
+// Returns the completed line and the candidates matching the word
+func Complete(line string, saves []string) (string, []string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasSuffix(line, " ") {
+		fields = append(fields, "")
+	}
+	word := fields[len(fields)-1]
+
+	var candidates []string
+	switch len(fields) {
+	case 1:
+		for _, name := range commandNames {
+			candidates = append(candidates, string(name))
+		}
+	case 2:
+		switch CommandName(fields[0]) {
+		case Set:
+			candidates = setOptions
+		case Preset:
+			candidates = presets.GetPresetNames()
+		case Load, Save:
+			candidates = saves
+		}
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return line, matches
+	}
+
+	completed := getCommonPrefix(matches)
+	if len(matches) == 1 {
+		completed += " "
+	}
+	fields[len(fields)-1] = completed
+	return strings.Join(fields, " "), matches
+}
+
+func getCommonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,70 @@
+package commandline
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	flags "sweep/config/flags"
+)
+
+func Test_Parse(t *testing.T) {
+	type TestCase struct {
+		line     string
+		expected error
+		flags    flags.Flags
+	}
+
+	testCases := []TestCase{
+		{line: "new 30 16 99", flags: flags.Flags{flags.WIDTH, "30", flags.HEIGHT, "16", flags.MINES, "99"}},
+		{line: "new", flags: flags.Flags{}},
+		{line: "  seed   1234 ", flags: flags.Flags{flags.SEED, "1234"}},
+		{line: "set fill", flags: flags.Flags{flags.FILL}},
+		{line: "set mines 40", flags: flags.Flags{flags.MINES, "40"}},
+		{line: "preset expert", flags: flags.Flags{flags.PRESET, "expert"}},
+		{line: "goto 12 40", flags: flags.Flags{}},
+		{line: "q", flags: flags.Flags{}},
+		{line: "new 30 16", expected: &WrongArgumentCountError{New}},
+		{line: "save", expected: &WrongArgumentCountError{Save}},
+		{line: "set help", expected: &InvalidOptionError{"help"}},
+		{line: "wq", expected: &UnknownCommandError{"wq"}},
+	}
+
+	for n, testCase := range testCases {
+		command, err := Parse(testCase.line)
+		if !errors.Is(err, testCase.expected) {
+			t.Errorf("[Assertion failed] #%v error\nexpected: %v, actual: %v", n+1, testCase.expected, err)
+			continue
+		}
+		if err == nil && !slices.Equal(command.ToFlags(), testCase.flags) {
+			t.Errorf("[Assertion failed] #%v flags\nexpected: %v, actual: %v", n+1, testCase.flags, command.ToFlags())
+		}
+	}
+}
+
+func Test_Complete(t *testing.T) {
+	type TestCase struct {
+		line     string
+		expected string
+		matches  []string
+	}
+
+	saves := []string{"quick", "quiet"}
+
+	testCases := []TestCase{
+		{line: "pr", expected: "preset ", matches: []string{"preset"}},
+		{line: "s", expected: "s", matches: []string{"seed", "save", "set"}},
+		{line: "preset ", expected: "preset ", matches: []string{"beginner", "expert", "intermediate"}},
+		{line: "preset ex", expected: "preset expert ", matches: []string{"expert"}},
+		{line: "load qu", expected: "load qui", matches: []string{"quick", "quiet"}},
+		{line: "set no", expected: "set no-flag ", matches: []string{"no-flag"}},
+		{line: "goto 1", expected: "goto 1", matches: nil},
+	}
+
+	for n, testCase := range testCases {
+		actual, matches := Complete(testCase.line, saves)
+		if actual != testCase.expected || !slices.Equal(matches, testCase.matches) {
+			t.Errorf("[Assertion failed] #%v\nexpected: %q %v, actual: %q %v", n+1, testCase.expected, testCase.matches, actual, matches)
+		}
+	}
+}
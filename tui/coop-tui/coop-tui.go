@@ -0,0 +1,232 @@
+package cooptui
+
+import (
+	"fmt"
+	"strings"
+
+	coop "sweep/coop"
+	actions "sweep/shared/consts/actions"
+	misc "sweep/shared/consts/misc"
+	tilecontent "sweep/shared/consts/tile-content"
+	types "sweep/shared/types"
+	navigation "sweep/tui/navigation"
+	styles "sweep/tui/styles"
+	tilerenderer "sweep/tui/tile-renderer"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+type updateMsg struct {
+	ok bool
+}
+
+func waitForUpdate(client *coop.Client) tea.Cmd {
+	return func() tea.Msg {
+		_, ok := <-client.Updates()
+		return updateMsg{ok}
+	}
+}
+
+// Co-op game on the field of the server
+// The cursor is moved locally, opening and flagging are sent to the server
+type model struct {
+	keyPressBuffer         string
+	previousKeyPressBuffer string
+	client                 *coop.Client
+	game                   coop.Game
+	cursorPosition         types.Position
+	disconnected           bool
+}
+
+var _ tea.Model = model{}
+
+func CreateModel(client *coop.Client) model {
+	game := client.GetGame()
+	return model{
+		client: client,
+		game:   game,
+		cursorPosition: types.Position{
+			X: game.Width / 2,
+			Y: game.Height / 2,
+		},
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(tea.SetWindowTitle(misc.AppName), waitForUpdate(m.client))
+}
+
+func (m *model) moveCursor(dx, dy int32) {
+	x := min(max(int32(m.cursorPosition.X)+dx, 0), int32(m.game.Width)-1)
+	y := min(max(int32(m.cursorPosition.Y)+dy, 0), int32(m.game.Height)-1)
+	m.cursorPosition = types.Position{X: uint16(x), Y: uint16(y)}
+}
+
+func (m *model) doAction(action *actions.Action) {
+	quantifier := int32(action.Quantifier)
+	previousPosition := m.cursorPosition
+
+	switch action.Kind {
+	case actions.MoveCursorUp:
+		m.moveCursor(0, quantifier)
+	case actions.MoveCursorDown:
+		m.moveCursor(0, -quantifier)
+	case actions.MoveCursorLeft:
+		m.moveCursor(-quantifier, 0)
+	case actions.MoveCursorRight:
+		m.moveCursor(quantifier, 0)
+	case actions.MoveCursorToTopRow:
+		m.cursorPosition.Y = m.game.Height - min(max(action.Quantifier, 1), m.game.Height)
+	case actions.MoveCursorToBottomRow:
+		m.cursorPosition.Y = 0
+	case actions.MoveCursorToFirstColumn:
+		m.cursorPosition.X = 0
+	case actions.MoveCursorToLastColumn:
+		m.cursorPosition.X = m.game.Width - 1
+	case actions.OpenTile:
+		m.client.Open(m.cursorPosition)
+	case actions.FlagTile:
+		m.client.Flag(m.cursorPosition)
+	}
+
+	if m.cursorPosition != previousPosition {
+		m.client.MoveCursor(m.cursorPosition)
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	// The program of the game ends with it so the client is closed
+	case navigation.ExitMsg:
+		return m, tea.Quit
+
+	case updateMsg:
+		if !msg.ok {
+			m.disconnected = true
+			return m, nil
+		}
+		return m, waitForUpdate(m.client)
+
+	case tea.KeyMsg:
+		msgString := msg.String()
+
+		// The game of everyone is left only by quitting, a finished one ends the program
+		if m.client.IsFinished() || m.disconnected {
+			if kind, ok := actions.GetScreenAction(actions.EndScreenContext, msgString); ok && kind == actions.Quit {
+				return m, navigation.Exit
+			}
+			return m, nil
+		}
+		switch kind, _ := actions.GetBoundAction(msgString); kind {
+		case actions.Quit:
+			return m, navigation.Exit
+		case actions.ClearKeyBuffer:
+			m.previousKeyPressBuffer = m.keyPressBuffer
+			m.keyPressBuffer = ""
+			return m, nil
+		}
+		m.keyPressBuffer += msgString
+
+		if !actions.AnyBindingStartWith(m.keyPressBuffer) {
+			m.previousKeyPressBuffer = m.keyPressBuffer
+			m.keyPressBuffer = ""
+			return m, nil
+		}
+
+		action, err := actions.GetAction(m.keyPressBuffer)
+		if err != nil {
+			return m, nil
+		}
+
+		m.previousKeyPressBuffer = m.keyPressBuffer
+		m.keyPressBuffer = ""
+
+		m.doAction(action)
+	}
+
+	return m, nil
+}
+
+func (m model) renderHeader(s *strings.Builder) {
+	var flags int
+	for y := range m.game.Height {
+		for x := range m.game.Width {
+			if m.client.GetTile(types.Position{X: x, Y: y}) == tilecontent.Flag {
+				flags++
+			}
+		}
+	}
+	s.WriteString(styles.HeaderStyle.Render(fmt.Sprintf("%v %v/%v", misc.AppName, flags, m.game.Mines)))
+
+	for ix, cursor := range m.client.GetCursors() {
+		name := cursor.Name
+		if cursor.ID == m.client.GetID() {
+			name = ">" + name
+		}
+		s.WriteString(" " + styles.GetPlayerStyle(ix).Render(name))
+	}
+}
+
+func (m model) renderTiles(s *strings.Builder) {
+	// Other players are shown by their colour
+	others := map[types.Position]int{}
+	for ix, cursor := range m.client.GetCursors() {
+		if cursor.ID != m.client.GetID() {
+			others[cursor.Position] = ix
+		}
+	}
+
+	for row := range m.game.Height {
+		y := m.game.Height - 1 - row
+		var line string
+		for x := range m.game.Width {
+			position := types.Position{X: x, Y: y}
+			tile := m.client.GetTile(position)
+			if position == m.cursorPosition {
+				line += tilerenderer.RenderTileByContent(tile, true)
+			} else if ix, ok := others[position]; ok {
+				line += tilerenderer.RenderTileWithStyle(tile, styles.GetPlayerStyle(ix), true)
+			} else {
+				line += tilerenderer.RenderTileByContent(tile, false)
+			}
+		}
+		s.WriteString("\n")
+		if row == 0 {
+			s.WriteString(styles.BorderTop.Render(line))
+		} else if row == m.game.Height-1 {
+			s.WriteString(styles.BorderBottom.Render(line))
+		} else {
+			s.WriteString(line)
+		}
+	}
+	s.WriteRune('\n')
+}
+
+func (m model) renderFooter(s *strings.Builder) {
+	var status string
+	switch {
+	case m.disconnected:
+		status = styles.WarningText.Render("the server is gone")
+	case m.client.IsFinished() && m.client.IsWon():
+		status = styles.BrightText.Render("you won")
+	case m.client.IsFinished():
+		status = styles.WarningText.Render("you lost")
+	}
+
+	keysStr := m.keyPressBuffer
+	if keysStr == "" {
+		keysStr = m.previousKeyPressBuffer
+	}
+
+	margin := int(m.game.Width)*3 - lipgloss.Width(status)
+	s.WriteString(status + styles.MarginLeft(margin, keysStr))
+}
+
+func (m model) View() string {
+	var s strings.Builder
+	m.renderHeader(&s)
+	m.renderTiles(&s)
+	m.renderFooter(&s)
+	return styles.TableStyle.Render(s.String())
+}
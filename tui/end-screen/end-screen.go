@@ -2,33 +2,120 @@ package endscreen
 
 import (
 	"fmt"
-	"os"
+	"slices"
 	"strings"
 	"time"
 
+	actions "sweep/shared/consts/actions"
 	misc "sweep/shared/consts/misc"
 	tilecontent "sweep/shared/consts/tile-content"
 	tiles "sweep/shared/consts/tiles"
 	types "sweep/shared/types"
 	"sweep/shared/utils"
+	navigation "sweep/tui/navigation"
 	styles "sweep/tui/styles"
 	tilerenderer "sweep/tui/tile-renderer"
+	viewport "sweep/tui/viewport"
 
 	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
 )
 
+// Global actions taken on the end screen in the order their keys are shown
+var endActions = []actions.ActionType{actions.NewGame, actions.RestartSameBoard, actions.MainMenu, actions.Quit}
+
 type model struct {
 	gameEngine types.IGameEngine
-	startTime  time.Time
+	duration   time.Duration
+	noFlags    bool
+	// Actions the screen is left with, races only end with quitting
+	available []actions.ActionType
+	// Points of every player of a hot-seat game in the order of turns
+	scores []uint16
+	// Player who hit each mine of a hot-seat game
+	explosionOwners []int
+	// Lives of the whole game, the mines hit are listed only when there were several
+	lives uint16
+	looks tilerenderer.Looks
+	// Last position of the cursor in the game, the field is centered on it when it does not fit the screen
+	cursor       types.Position
+	viewport     viewport.Viewport
+	screenWidth  int
+	screenHeight int
 }
 
-func CreateModel(startTime time.Time, gameEngine types.IGameEngine) model {
+func CreateModel(duration time.Duration, gameEngine types.IGameEngine, noFlags bool) model {
 	return model{
-		startTime:  startTime,
+		duration:   duration,
 		gameEngine: gameEngine,
+		noFlags:    noFlags,
+		available:  endActions,
+		lives:      1,
+		viewport:   viewport.CreateViewport(gameEngine.GetWidth(), gameEngine.GetHeight(), 0),
 	}
 }
 
+// Leaves only the given actions to the keys of the screen
+func (m model) WithActions(kinds ...actions.ActionType) model {
+	m.available = kinds
+	return m
+}
+
+// Centers the field on the position when it does not fit the screen
+func (m model) WithCursor(position types.Position) model {
+	m.cursor = position
+	m.resize()
+	return m
+}
+
+// Takes the size of the screen the game was shown on, the app sends it again on resizing
+func (m model) WithSize(width, height int) model {
+	m.screenWidth, m.screenHeight = width, height
+	m.resize()
+	return m
+}
+
+// Shows the field the way the game showed it
+func (m model) WithLooks(looks tilerenderer.Looks) model {
+	m.looks = looks
+	return m
+}
+
+// Lists where the lives were lost when the game had several of them
+func (m model) WithLives(lives uint16) model {
+	m.lives = lives
+	return m
+}
+
+// Announces the players with the most points instead of whether the field was cleared
+// and tells who hit every mine
+func (m model) WithScores(scores []uint16, explosionOwners []int) model {
+	m.scores = scores
+	m.explosionOwners = explosionOwners
+	return m
+}
+
+// Lists where every life was lost, a game with a single life ends with the mine shown on the field
+func (m model) renderLostLives() string {
+	if m.lives <= 1 {
+		return ""
+	}
+	var s strings.Builder
+	height := m.gameEngine.GetHeight()
+	lost := make([]int, len(m.scores))
+	for ix, position := range m.gameEngine.GetExplosions() {
+		if ix < len(m.explosionOwners) && len(m.scores) > 1 {
+			owner := m.explosionOwners[ix]
+			lost[owner]++
+			player := styles.GetPlayerStyle(owner).Render(fmt.Sprintf("P%v", owner+1))
+			fmt.Fprintf(&s, "\n%v lost life #%v at column %v, row %v", player, lost[owner], position.X+1, height-position.Y)
+			continue
+		}
+		fmt.Fprintf(&s, "\nlife #%v lost at column %v, row %v", ix+1, position.X+1, height-position.Y)
+	}
+	return s.String()
+}
+
 func (m model) Init() tea.Cmd {
 	return tea.Batch(tea.SetWindowTitle(misc.AppName), tea.ClearScreen)
 }
@@ -36,78 +123,137 @@ func (m model) Init() tea.Cmd {
 var _ tea.Model = model{}
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		key := msg.String()
-		switch key {
-		case "ctrl+c", "q":
-			os.Exit(0)
-		default:
-			return m, tea.Quit
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		return m.WithSize(msg.Width, msg.Height), nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	kind, ok := actions.GetScreenAction(actions.EndScreenContext, keyMsg.String())
+	if !ok || !slices.Contains(m.available, kind) {
+		return m, nil
+	}
+	cmd, _ := navigation.FromAction(kind)
+	return m, cmd
+}
+
+// Every player with the best score wins, so several of them make a draw
+func (m model) renderWinners() string {
+	best := slices.Max(m.scores)
+	var winners []string
+	for ix, score := range m.scores {
+		if score == best {
+			winners = append(winners, styles.GetPlayerStyle(ix).Render(fmt.Sprintf("P%v", ix+1)))
 		}
 	}
-	return m, nil
+	if len(winners) > 1 {
+		return fmt.Sprintf("Draw between %v!", strings.Join(winners, ", "))
+	}
+	return fmt.Sprintf("%v won!", winners[0])
 }
 
-func (m model) View() string {
-	var lines strings.Builder
+// Lists the keys of every action the screen could be left with
+func (m model) renderHints() string {
+	var hints []string
+	for _, kind := range endActions {
+		keys := actions.GetScreenKeys(actions.EndScreenContext, kind)
+		if len(keys) == 0 || !slices.Contains(m.available, kind) {
+			continue
+		}
+		hints = append(hints, fmt.Sprintf("%v %v", strings.Join(keys, "/"), kind))
+	}
+	return styles.DimText.Render(strings.Join(hints, " · "))
+}
 
-	win := true
+// The part of the field that fits the screen is shown around the last position of the cursor
+func (m *model) resize() {
+	if m.screenWidth == 0 || m.screenHeight == 0 {
+		return
+	}
+	// Table borders, field borders and the hints
+	height := m.screenHeight - lipgloss.Height(m.renderTitle()) - lipgloss.Height(m.renderFooter()) - 5
+	width := m.screenWidth - 2
+	m.viewport.Resize(uint16(max(width/tilerenderer.TileWidth, 1)), uint16(max(height, 1)))
+	m.viewport.Center(m.cursor.X, m.gameEngine.GetHeight()-1-m.cursor.Y)
+}
 
-	field := m.gameEngine.GetField()
+func (m model) renderTitle() string {
+	isWon := m.gameEngine.IsWon()
+	if len(m.scores) > 1 {
+		return m.renderWinners()
+	} else if isWon && m.noFlags {
+		return "You won with no flags!"
+	} else if isWon {
+		return "You won!"
+	}
+	return "You lost!"
+}
 
-	width, height := m.gameEngine.GetWidth(), m.gameEngine.GetHeight()
+func (m model) renderField() string {
+	var lines strings.Builder
 
-	for row := range height {
+	isWon := m.gameEngine.IsWon()
+	height := m.gameEngine.GetHeight()
+	first, last := m.viewport.Row, m.viewport.Row+m.viewport.Height-1
+
+	for row := first; row <= last; row++ {
 		var line string
 		y := height - 1 - row
-		for col := range width {
-			x := col
-
+		for col := m.viewport.Column; col < m.viewport.Column+m.viewport.Width; col++ {
 			position := types.Position{
-				X: x,
+				X: col,
 				Y: y,
 			}
 
 			tile := m.gameEngine.GetTile(position)
-
-			if tile == tiles.OpenMine {
-				win = false
+			// Remaining mines are flagged automatically on a win
+			if isWon && tile == tiles.ClosedMine {
+				tile = tiles.FlaggedMine
 			}
 
-			count := m.gameEngine.CountNeighbouringMines(types.Position{
-				X: uint16(x),
-				Y: uint16(y),
-			})
+			count := m.gameEngine.CountNeighbouringMines(position)
 			tileContent, err := tilecontent.FromNumber(count)
 			if err != nil {
 				panic(err)
 			}
-			line += tilerenderer.RenderTileByType(tile, tileContent)
+			line += m.looks.RenderTileByType(tile, tileContent)
 		}
 
 		lines.WriteRune('\n')
-		if row == 0 {
+		if row == first {
 			lines.WriteString(styles.BorderTop.Render(line))
-		} else if row == uint16(len(field)-1) {
+		} else if row == last {
 			lines.WriteString(styles.BorderBottom.Render(line))
 		} else {
 			lines.WriteString(line)
 		}
 	}
+	return lines.String()
+}
 
+func (m model) renderFooter() string {
 	var s strings.Builder
-	if win {
-		s.WriteString("You won!")
-	} else {
-		s.WriteString("You lost!")
+	fmt.Fprintf(&s, "time - %v", utils.FormatTime(m.duration))
+
+	for ix, score := range m.scores {
+		fmt.Fprintf(&s, "\n%v - %v", styles.GetPlayerStyle(ix).Render(fmt.Sprintf("P%v", ix+1)), score)
 	}
 
-	s.WriteString(lines.String())
-	s.WriteRune('\n')
+	s.WriteString(m.renderLostLives())
+	return s.String()
+}
 
-	formattedDuration := utils.FormatTime(time.Since(m.startTime))
+func (m model) View() string {
+	var s strings.Builder
+	s.WriteString(m.renderTitle())
+	s.WriteString(m.renderField())
+	s.WriteRune('\n')
+	s.WriteString(m.renderFooter())
 
-	fmt.Fprintf(&s, "time - %v", formattedDuration)
-	return styles.TableStyle.Render(s.String())
+	table := styles.TableStyle.Render(s.String())
+	if hints := m.renderHints(); hints != "" {
+		return table + "\n" + hints
+	}
+	return table
 }
@@ -0,0 +1,238 @@
+package gametui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	config "sweep/config"
+	flags "sweep/config/flags"
+	gameengine "sweep/game-engine"
+	saves "sweep/saves"
+	actions "sweep/shared/consts/actions"
+	types "sweep/shared/types"
+	commandline "sweep/tui/command-line"
+	navigation "sweep/tui/navigation"
+	styles "sweep/tui/styles"
+
+	textinput "github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type CommandNotAvailableError struct {
+	command commandline.CommandName
+}
+
+func (e *CommandNotAvailableError) Error() string {
+	return fmt.Sprintf("%v is not available in a race", e.command)
+}
+
+func (e *CommandNotAvailableError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type InvalidGotoPositionError struct {
+	column string
+	row    string
+}
+
+func (e *InvalidGotoPositionError) Error() string {
+	return fmt.Sprintf("there is no tile in column %v row %v", e.column, e.row)
+}
+
+func (e *InvalidGotoPositionError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+func createCommandInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = ":"
+	input.PromptStyle = styles.BrightText
+	input.TextStyle = styles.BrightText
+	return input
+}
+
+func (m *model) OpenCommandLine(_ uint16) {
+	m.isTyping = true
+	m.commandInput.Reset()
+	m.commandInput.Width = max(m.getGutterWidth()+m.getFieldWidth()-2, 1)
+	m.commandInput.Focus()
+}
+
+func (m *model) closeCommandLine() {
+	m.isTyping = false
+	m.commandInput.Blur()
+}
+
+func (m *model) setMessage(message string, isError bool) {
+	m.message = message
+	m.isErrorMessage = isError
+}
+
+// Keys typed into the command line are not taken for the bindings, only the quit keys that are not letters are
+func (m model) updateCommandLine(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type != tea.KeyRunes && actions.IsBinding(msg.String(), actions.Quit) {
+		return m, navigation.Exit
+	}
+	switch msg.String() {
+	case "esc":
+		m.closeCommandLine()
+		return m, nil
+	case "tab":
+		line, _ := commandline.Complete(m.commandInput.Value(), saves.List())
+		m.commandInput.SetValue(line)
+		m.commandInput.CursorEnd()
+		return m, nil
+	case "enter":
+		line := m.commandInput.Value()
+		m.closeCommandLine()
+		if strings.TrimSpace(line) == "" {
+			return m, nil
+		}
+		return m.runCommand(line)
+	}
+
+	var cmd tea.Cmd
+	m.commandInput, cmd = m.commandInput.Update(msg)
+	return m, cmd
+}
+
+func (m model) runCommand(line string) (tea.Model, tea.Cmd) {
+	command, err := commandline.Parse(line)
+	if err != nil {
+		m.setMessage(err.Error(), true)
+		return m, nil
+	}
+
+	switch command.Name {
+	case commandline.New, commandline.Seed, commandline.Preset, commandline.Load:
+		if m.race != nil {
+			m.setMessage((&CommandNotAvailableError{command.Name}).Error(), true)
+			return m, nil
+		}
+	}
+
+	switch command.Name {
+	case commandline.Quit:
+		return m, navigation.Exit
+	case commandline.New, commandline.Seed, commandline.Preset:
+		return m.newGame(command.ToFlags())
+	case commandline.Load:
+		return m.loadGame(command.Args[0])
+	case commandline.Save:
+		err = saves.Save(command.Args[0], m.getSave())
+		if err == nil {
+			m.setMessage(fmt.Sprintf("saved %v", command.Args[0]), false)
+		}
+	case commandline.Set:
+		var next config.Config
+		next, err = m.applyFlags(command.ToFlags())
+		if err == nil {
+			// Options of the field are left for the next game, the looks change right away
+			*m.sharedConfig = next
+			m.config.Fill, m.config.ASCII = next.Fill, next.ASCII
+			m.setMessage(fmt.Sprintf("set %v", strings.Join(command.Args, " ")), false)
+		}
+	case commandline.Goto:
+		err = m.goTo(command.Args[0], command.Args[1])
+	}
+
+	if err != nil {
+		m.setMessage(err.Error(), true)
+	}
+	return m, nil
+}
+
+// Validates the flags the same way config.LoadConfig does with the ones from the command line
+// and sets them on a copy of the config of the session
+func (m model) applyFlags(commandFlags flags.Flags) (config.Config, error) {
+	if isValid, errors := commandFlags.Validate(); !isValid {
+		return m.config, errors[0]
+	}
+
+	next := m.sharedConfig.WithFlags(commandFlags)
+	if isValid, errors := next.Validate(); !isValid {
+		return m.config, errors[0]
+	}
+	return next, nil
+}
+
+func validateField(conf config.Config) error {
+	engine := gameengine.GameEngine{}
+	if err := engine.SetFieldSize(conf.Width, conf.Height); err != nil {
+		return err
+	}
+	return engine.SetMineCount(conf.Mines)
+}
+
+// The new game takes the size of the screen from the current one
+func (m model) replaceWith(game model) (tea.Model, tea.Cmd) {
+	game.screenWidth = m.screenWidth
+	game.screenHeight = m.screenHeight
+	game.resizeViewport()
+	return game, game.Init()
+}
+
+func (m model) newGame(commandFlags flags.Flags) (tea.Model, tea.Cmd) {
+	next, err := m.applyFlags(commandFlags)
+	if err == nil {
+		err = validateField(next)
+	}
+	if err != nil {
+		m.setMessage(err.Error(), true)
+		return m, nil
+	}
+
+	*m.sharedConfig = next
+	return m.replaceWith(CreateModel(m.sharedConfig))
+}
+
+func (m model) getSave() saves.Game {
+	return saves.Game{
+		Width:        m.config.Width,
+		Height:       m.config.Height,
+		Mines:        m.config.Mines,
+		Lives:        m.config.Lives,
+		Players:      m.config.Players,
+		TimeLimit:    m.config.TimeLimit,
+		TimeBonus:    m.config.TimeBonus,
+		WinCondition: m.config.WinCondition,
+		NoFlag:       m.config.NoFlag,
+		Seed:         m.seed,
+		Moves:        m.moves,
+		Cursor:       m.cursorPosition,
+		Duration:     m.getElapsed(),
+	}
+}
+
+func (m model) loadGame(name string) (tea.Model, tea.Cmd) {
+	save, err := saves.Load(name)
+	var game model
+	if err == nil {
+		game, err = CreateFromSave(m.sharedConfig, save)
+	}
+	if err != nil {
+		m.setMessage(err.Error(), true)
+		return m, nil
+	}
+
+	game.setMessage(fmt.Sprintf("loaded %v", name), false)
+	return m.replaceWith(game)
+}
+
+// Takes the column from the left and the row from the top counting from 1 as they are seen on the screen
+func (m *model) goTo(column, row string) error {
+	x, errX := strconv.ParseUint(column, 10, 16)
+	y, errY := strconv.ParseUint(row, 10, 16)
+	if errX != nil || errY != nil || x == 0 || y == 0 || x > uint64(m.config.Width) || y > uint64(m.config.Height) {
+		return &InvalidGotoPositionError{column, row}
+	}
+
+	from := m.cursorPosition
+	m.cursorPosition = types.Position{X: uint16(x) - 1, Y: m.config.Height - uint16(y)}
+	if m.cursorPosition != from {
+		m.jumps.Push(from)
+	}
+	m.followCursor()
+	return nil
+}
@@ -2,23 +2,36 @@ package gametui
 
 import (
 	"fmt"
-	"os"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	config "sweep/config"
 	gameengine "sweep/game-engine"
+	history "sweep/history"
+	race "sweep/race"
+	saves "sweep/saves"
 	actions "sweep/shared/consts/actions"
+	densities "sweep/shared/consts/densities"
 	misc "sweep/shared/consts/misc"
 	tilecontent "sweep/shared/consts/tile-content"
 	tiles "sweep/shared/consts/tiles"
 	types "sweep/shared/types"
 	utils "sweep/shared/utils"
-	endscreen "sweep/tui/end-screen"
+	jumplist "sweep/tui/jump-list"
+	minimap "sweep/tui/minimap"
+	motions "sweep/tui/motions"
+	navigation "sweep/tui/navigation"
+	standings "sweep/tui/standings"
 	styles "sweep/tui/styles"
 	tilerenderer "sweep/tui/tile-renderer"
+	viewport "sweep/tui/viewport"
+	whichkey "sweep/tui/which-key"
 
+	textinput "github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
 )
 
 type Tiles [][]tilecontent.TileContent
@@ -28,6 +41,37 @@ func (t *Tiles) SetTile(position types.Position, tile tilecontent.TileContent) {
 	(*t)[y][x] = tile
 }
 
+// Holds which player revealed each tile
+// 0 is for nobody, otherwise it's the player index + 1
+type Owners [][]uint8
+
+func CreateOwners(width, height uint16) *Owners {
+	owners := make(Owners, height)
+	for y := range height {
+		owners[y] = make([]uint8, width)
+	}
+	return &owners
+}
+
+func (o *Owners) SetOwner(position types.Position, player int) {
+	x, y := position.GetCoords()
+	(*o)[y][x] = uint8(player + 1)
+}
+
+// Second return value is whether the tile has an owner
+func (o Owners) GetOwner(position types.Position) (int, bool) {
+	x, y := position.GetCoords()
+	if int(y) >= len(o) || int(x) >= len(o[y]) || o[y][x] == 0 {
+		return 0, false
+	}
+	return int(o[y][x]) - 1, true
+}
+
+type player struct {
+	score uint16
+	lives uint16
+}
+
 type TileOutOfBoundsError struct {
 	position types.Position
 }
@@ -58,10 +102,71 @@ func CreateTiles(width, height uint16) *Tiles {
 	return &tiles
 }
 
+const (
+	tickInterval         = 100 * time.Millisecond
+	timeWarningThreshold = 10 * time.Second
+	defaultMinimapSize   = 20
+	// Milliseconds a binding waits for the keys of a longer one like timeoutlen in VIM
+	defaultTimeoutlen = 1000
+)
+
+// Every game has its own ticks, the ones of the game it replaced are dropped so only one chain keeps going
+type tickMsg struct {
+	generation uint64
+}
+
+// Games of every session of the process are counted together so the generations never repeat
+var lastGeneration atomic.Uint64
+
+// Sent once the time for the next key of a sequence is over
+// Only the last key press resolves the buffer, the earlier ones are outdated by it
+type keyTimeoutMsg struct {
+	keyPresses uint64
+}
+
+type standingsMsg []race.Progress
+
+func waitForStandings(peer race.Peer) tea.Cmd {
+	return func() tea.Msg {
+		return standingsMsg(<-peer.Standings())
+	}
+}
+
+func tick(generation uint64) tea.Cmd {
+	return tea.Tick(tickInterval, func(_ time.Time) tea.Msg {
+		return tickMsg{generation}
+	})
+}
+
 type model struct {
-	screenWidth            int
+	screenWidth  int
+	screenHeight int
+	viewport     viewport.Viewport
+	density      densities.Density
+	showMinimap  bool
+	marks        map[rune]types.Position
+	jumps        jumplist.JumpList
+	macros       map[rune][]actions.Action
+	// The register the macro is recorded into, 0 when nothing is recorded
+	recordingRegister rune
+	// Registers of the macros being played, the innermost last
+	playingRegisters []rune
+	// The last action that changed the field, repeated with the dot
+	lastChange *actions.Action
+	// The config the game was created with, options set from the command line are kept there for the next games
+	sharedConfig *config.Config
+	seed         int64
+	// Actions taken on the field so the game could be saved and replayed
+	moves                  []saves.Move
+	isTyping               bool
+	isHelpShown            bool
+	helpOffset             int
+	commandInput           textinput.Model
+	message                string
+	isErrorMessage         bool
 	keyPressBuffer         string
 	previousKeyPressBuffer string
+	keyPresses             uint64
 	config                 config.Config
 	cursorPosition         types.Position
 	gameEngine             types.IGameEngine
@@ -69,6 +174,28 @@ type model struct {
 	startTime              time.Time
 	openedATile            bool
 	flags                  int16
+	timeBonus              time.Duration
+	usedFlags              bool
+	isPaused               bool
+	pausedAt               time.Time
+	pausedDuration         time.Duration
+	duration               time.Duration
+	owners                 Owners
+	players                []player
+	currentPlayer          int
+	race                   race.Peer
+	standings              []race.Progress
+	pressedButton          tea.MouseButton
+	// Player who hit each of the mines in the order of the explosions
+	explosionOwners []int
+	// Whether the end screen was sent to the app once the game was over
+	isOverSent bool
+	isReplay   bool
+	// Moves played back by the replay and how many of them were played so far
+	replay   []saves.Move
+	replayed int
+	// Tells the ticks of the game from the ones still pending for the game it replaced
+	generation uint64
 }
 
 func CreateModel(config *config.Config) model {
@@ -81,6 +208,24 @@ func CreateModel(config *config.Config) model {
 	if err != nil {
 		fmt.Println(err)
 	}
+	gameEngine.SetWinCondition(config.WinCondition)
+	// Every field gets a seed so the game could be saved
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	gameEngine.SetSeed(seed)
+
+	lives := max(config.Lives, 1)
+	players := make([]player, max(config.Players, 1))
+	for ix := range players {
+		players[ix].lives = lives
+	}
+	// Every player has their own lives, a player is out once they are all lost
+	err = gameEngine.SetLives(lives * uint16(len(players)))
+	if err != nil {
+		fmt.Println(err)
+	}
 
 	return model{
 		cursorPosition: types.Position{
@@ -89,15 +234,78 @@ func CreateModel(config *config.Config) model {
 		},
 		gameEngine:     &gameEngine,
 		tiles:          *CreateTiles(config.Width, config.Height),
+		viewport:       viewport.CreateViewport(config.Width, config.Height, config.ScrollMargin),
+		density:        config.Density,
+		showMinimap:    true,
+		marks:          map[rune]types.Position{},
+		macros:         map[rune][]actions.Action{},
+		sharedConfig:   config,
+		seed:           seed,
+		commandInput:   createCommandInput(),
+		owners:         *CreateOwners(config.Width, config.Height),
+		players:        players,
 		startTime:      time.Now(),
 		openedATile:    false,
 		config:         *config,
 		keyPressBuffer: "",
+		generation:     lastGeneration.Add(1),
+	}
+}
+
+// Makes the game a part of the race
+// Everyone starts with the center of the same seeded field open
+func (m model) WithRace(peer race.Peer) model {
+	m.race = peer
+	m.OpenTile(1)
+	m.reportProgress()
+	return m
+}
+
+func (m model) reportProgress() {
+	if m.race == nil {
+		return
 	}
+	safeTileCount := uint32(m.config.Width)*uint32(m.config.Height) - uint32(m.config.Mines)
+	percent := min(uint32(m.gameEngine.GetOpenCount())*100/max(safeTileCount, 1), 100)
+
+	m.race.Report(race.Progress{
+		Percent:  uint8(percent),
+		Alive:    !m.gameEngine.IsFinished() || m.gameEngine.IsWon(),
+		Finished: m.gameEngine.IsFinished(),
+		Duration: m.getElapsed(),
+	})
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.SetWindowTitle(misc.AppName)
+	cmds := []tea.Cmd{tea.SetWindowTitle(misc.AppName), tick(m.generation)}
+	if m.race != nil {
+		cmds = append(cmds, waitForStandings(m.race))
+	}
+	if m.isReplay {
+		cmds = append(cmds, waitForReplay())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m model) hasTimeLimit() bool {
+	return m.config.TimeLimit != 0
+}
+
+func (m model) getTimeLeft() time.Duration {
+	timeLimit := time.Duration(m.config.TimeLimit) * time.Second
+	return timeLimit + m.timeBonus - m.getElapsed()
+}
+
+// Returns time spent playing excluding paused intervals
+func (m model) getElapsed() time.Duration {
+	if m.gameEngine.IsFinished() {
+		return m.duration
+	}
+	elapsed := time.Since(m.startTime) - m.pausedDuration
+	if m.isPaused {
+		elapsed -= time.Since(m.pausedAt)
+	}
+	return elapsed
 }
 
 var _ tea.Model = model{}
@@ -121,20 +329,34 @@ func (m *model) openTile(position types.Position) {
 		return
 	}
 
+	if m.gameEngine.GetTile(position) == tiles.OpenMine {
+		m.revealTile(position, tilecontent.Mine)
+		return
+	}
+
 	count := m.gameEngine.CountNeighbouringMines(position)
 	tileContent, err := tilecontent.FromNumber(count)
 	if err != nil {
 		panic(err)
 	}
 
-	m.tiles.SetTile(position, tileContent)
+	m.revealTile(position, tileContent)
 
 	if count == 0 {
 		m.openSafeAroundTile(position)
 	}
 }
 
-func (m model) openSafeAroundTile(position types.Position) {
+// Sets the tile content on behalf of the current player
+func (m *model) revealTile(position types.Position, tileContent tilecontent.TileContent) {
+	m.tiles.SetTile(position, tileContent)
+	m.owners.SetOwner(position, m.currentPlayer)
+	if tileContent != tilecontent.Mine {
+		m.players[m.currentPlayer].score++
+	}
+}
+
+func (m *model) openSafeAroundTile(position types.Position) {
 	x, y := position.GetCoords()
 
 	neighbours := []types.Position{
@@ -177,7 +399,8 @@ func (m *model) openAroundOpenTile(position types.Position) {
 	}
 
 	for _, position := range neighbours {
-		if tc, err := m.tiles.GetTile(position); err == nil && tc == tilecontent.Flag {
+		// Exploded mines are as good as flagged ones
+		if tc, err := m.tiles.GetTile(position); err == nil && (tc == tilecontent.Flag || tc == tilecontent.Mine) {
 			flagCount++
 		}
 	}
@@ -188,7 +411,7 @@ func (m *model) openAroundOpenTile(position types.Position) {
 
 	for _, position := range neighbours {
 		switch m.gameEngine.GetTile(position) {
-		case tiles.FlaggedMine, tiles.FlaggedSafe, tiles.OutOfBounds:
+		case tiles.FlaggedMine, tiles.FlaggedSafe, tiles.OpenMine, tiles.OutOfBounds:
 			continue
 		}
 		m.gameEngine.OpenTile(position)
@@ -199,6 +422,8 @@ func (m *model) openAroundOpenTile(position types.Position) {
 		}
 
 		switch m.gameEngine.GetTile(position) {
+		case tiles.OpenMine:
+			m.revealTile(position, tilecontent.Mine)
 		case tiles.OpenSafe:
 			count := m.gameEngine.CountNeighbouringMines(position)
 
@@ -207,7 +432,7 @@ func (m *model) openAroundOpenTile(position types.Position) {
 				panic(err)
 			}
 
-			m.tiles.SetTile(position, tileContent)
+			m.revealTile(position, tileContent)
 			if count == 0 {
 				m.openSafeAroundTile(position)
 			}
@@ -267,7 +492,7 @@ func (m *model) MoveCursorLeft(quantifier uint16) {
 }
 
 func (m *model) FlagTile(_ uint16) {
-	if !m.openedATile {
+	if !m.openedATile || m.config.NoFlag {
 		return
 	}
 
@@ -282,6 +507,7 @@ func (m *model) FlagTile(_ uint16) {
 	case tilecontent.Empty:
 		m.tiles.SetTile(m.cursorPosition, tilecontent.Flag)
 		m.flags++
+		m.usedFlags = true
 	case tilecontent.Flag:
 		m.tiles.SetTile(m.cursorPosition, tilecontent.Empty)
 		m.flags--
@@ -338,9 +564,256 @@ func (m *model) MoveCursorToLastColumn(quantifier uint16) {
 	m.cursorPosition.Y -= quantifier
 }
 
+// Rows of the viewport go from the top while the field counts them from the bottom
+func (m model) getCursorCell() (uint16, uint16) {
+	return m.cursorPosition.X, m.config.Height - 1 - m.cursorPosition.Y
+}
+
+func (m *model) followCursor() {
+	m.viewport.Follow(m.getCursorCell())
+}
+
+// Keeps the cursor on the screen when the field is scrolled from under it
+func (m *model) scroll(columns, rows int) {
+	m.viewport.Scroll(columns, rows)
+	column, row := m.viewport.Clamp(m.getCursorCell())
+	m.cursorPosition = types.Position{X: column, Y: m.config.Height - 1 - row}
+}
+
+func (m *model) ScrollUp(quantifier uint16) {
+	m.scroll(0, -int(quantifier))
+}
+
+func (m *model) ScrollDown(quantifier uint16) {
+	m.scroll(0, int(quantifier))
+}
+
+func (m *model) ScrollLeft(quantifier uint16) {
+	m.scroll(-int(quantifier), 0)
+}
+
+func (m *model) ScrollRight(quantifier uint16) {
+	m.scroll(int(quantifier), 0)
+}
+
+func (m *model) CenterCursor(_ uint16) {
+	m.viewport.Center(m.getCursorCell())
+}
+
+// Fits the viewport between the borders, the header and the footer
+func (m *model) resizeViewport() {
+	if m.screenWidth == 0 || m.screenHeight == 0 {
+		return
+	}
+	var header strings.Builder
+	m.renderHeader(&header)
+
+	width := m.screenWidth - 2 - m.getGutterWidth()
+	if m.race != nil {
+		width -= lipgloss.Width(standings.RenderStandings(m.standings, m.race.GetID()))
+	}
+	// Table borders, field borders and the footer
+	height := m.screenHeight - lipgloss.Height(header.String()) - 5
+
+	tileWidth, tileHeight := tilerenderer.GetTileSize(m.density)
+	m.viewport.Resize(uint16(max(width/tileWidth, 1)), uint16(max(height/tileHeight, 1)))
+	// The minimap takes space from the field only when the field does not fit anyway
+	if m.isMinimapShown() {
+		width -= lipgloss.Width(m.renderMinimap())
+		m.viewport.Resize(uint16(max(width/tileWidth, 1)), uint16(max(height/tileHeight, 1)))
+	}
+	m.followCursor()
+}
+
+func (m *model) SetMark(register rune) {
+	m.marks[register] = m.cursorPosition
+}
+
+func (m *model) JumpToMark(register rune) {
+	if position, ok := m.marks[register]; ok {
+		m.cursorPosition = position
+	}
+}
+
+func (m *model) JumpBack(quantifier uint16) {
+	for range quantifier {
+		position, ok := m.jumps.Back(m.cursorPosition)
+		if !ok {
+			return
+		}
+		m.cursorPosition = position
+	}
+}
+
+func (m *model) JumpForward(quantifier uint16) {
+	for range quantifier {
+		position, ok := m.jumps.Forward()
+		if !ok {
+			return
+		}
+		m.cursorPosition = position
+	}
+}
+
+// Only the motions that take the cursor far away are remembered in the jump list
+func isJump(kind actions.ActionType) bool {
+	switch kind {
+	case actions.MoveCursorToTopRow, actions.MoveCursorToBottomRow, actions.JumpToMark,
+		actions.MoveCursorToNextFrontierTile, actions.MoveCursorToPreviousFrontierTile,
+		actions.MoveCursorToNextUnsatisfiedTile, actions.MoveCursorToPreviousUnsatisfiedTile:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m model) isMinimapShown() bool {
+	v := m.viewport
+	return m.showMinimap && (v.HasMoreLeft() || v.HasMoreRight() || v.HasMoreAbove() || v.HasMoreBelow())
+}
+
+func (m *model) ToggleMinimap(_ uint16) {
+	m.showMinimap = !m.showMinimap
+	m.resizeViewport()
+}
+
+func (m model) renderMinimap() string {
+	size := m.config.MinimapSize
+	if size == 0 {
+		size = defaultMinimapSize
+	}
+	scale := minimap.GetScale(m.config.Width, m.config.Height, size)
+	column, row := m.getCursorCell()
+	return minimap.Render(m.tiles, scale, m.viewport, column, row)
+}
+
+func (m *model) CycleDensity(_ uint16) {
+	m.density = densities.Next(m.density)
+	m.resizeViewport()
+}
+
+// Repeats the motion as many times as the quantifier says or until there is nowhere to go
+func (m *model) moveCursorWith(quantifier uint16, motion func(motions.Tiles, types.Position, bool) (types.Position, bool), forward bool) {
+	for range quantifier {
+		position, ok := motion(m.tiles, m.cursorPosition, forward)
+		if !ok {
+			return
+		}
+		m.cursorPosition = position
+	}
+}
+
+func (m *model) MoveCursorToNextClosedTile(quantifier uint16) {
+	m.moveCursorWith(quantifier, motions.NextClosedInRow, true)
+}
+
+func (m *model) MoveCursorToPreviousClosedTile(quantifier uint16) {
+	m.moveCursorWith(quantifier, motions.NextClosedInRow, false)
+}
+
+func (m *model) MoveCursorToNextFrontierTile(quantifier uint16) {
+	m.moveCursorWith(quantifier, motions.NextFrontier, true)
+}
+
+func (m *model) MoveCursorToPreviousFrontierTile(quantifier uint16) {
+	m.moveCursorWith(quantifier, motions.NextFrontier, false)
+}
+
+func (m *model) MoveCursorToNextUnsatisfiedTile(quantifier uint16) {
+	m.moveCursorWith(quantifier, motions.NextUnsatisfied, true)
+}
+
+func (m *model) MoveCursorToPreviousUnsatisfiedTile(quantifier uint16) {
+	m.moveCursorWith(quantifier, motions.NextUnsatisfied, false)
+}
+
+func (m *model) finish() {
+	m.duration = time.Since(m.startTime) - m.pausedDuration
+	// The history keeps the games of a single player, the field of a hot-seat game is cleared by all of them
+	if m.isReplay || m.isMultiplayer() {
+		return
+	}
+
+	err := history.Save(history.Record{
+		Date:     time.Now(),
+		Width:    m.config.Width,
+		Height:   m.config.Height,
+		Mines:    m.config.Mines,
+		Won:      m.gameEngine.IsWon(),
+		Duration: m.duration,
+		NoFlags:  !m.usedFlags,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func (m model) isMultiplayer() bool {
+	return len(m.players) > 1
+}
+
+// Passes the turn if the current player has opened anything
+// and takes away lives for every mine hit during the turn
+func (m *model) endTurn(openCount uint16, explosionCount int) {
+	explosions := len(m.gameEngine.GetExplosions()) - explosionCount
+	player := &m.players[m.currentPlayer]
+	player.lives -= min(player.lives, uint16(explosions))
+	for range explosions {
+		m.explosionOwners = append(m.explosionOwners, m.currentPlayer)
+	}
+
+	if explosions == 0 && m.gameEngine.GetOpenCount() == openCount {
+		return
+	}
+
+	for range m.players {
+		m.currentPlayer = (m.currentPlayer + 1) % len(m.players)
+		if m.players[m.currentPlayer].lives > 0 {
+			return
+		}
+	}
+}
+
+// Opens tiles around the cursor if it is on an open tile with enough flags around it
+func (m *model) ChordTile(_ uint16) {
+	if m.gameEngine.GetTile(m.cursorPosition) == tiles.OpenSafe {
+		m.openAroundOpenTile(m.cursorPosition)
+	}
+}
+
+func (m *model) TogglePause(_ uint16) {
+	if m.isPaused {
+		m.pausedDuration += time.Since(m.pausedAt)
+	} else {
+		m.pausedAt = time.Now()
+	}
+	m.isPaused = !m.isPaused
+}
+
 func (m *model) doAction(action *actions.Action) {
 	quantifier := action.Quantifier
 
+	if m.isPaused && action.Kind != actions.Pause && action.Kind != actions.Help {
+		return
+	}
+
+	// The actions taking others are left to them to count the opened tiles and end the turns
+	switch action.Kind {
+	case actions.PlayMacro:
+		m.PlayMacro(action.Register, quantifier)
+		return
+	case actions.RepeatAction:
+		m.RepeatAction()
+		return
+	}
+
+	openCount := m.gameEngine.GetOpenCount()
+	explosionCount := len(m.gameEngine.GetExplosions())
+	defer func() {
+		opened := m.gameEngine.GetOpenCount() - openCount
+		m.timeBonus += time.Duration(opened) * time.Duration(m.config.TimeBonus) * time.Second
+	}()
+
 	var actionHandler func(uint16)
 	switch action.Kind {
 	case actions.FlagTile:
@@ -355,6 +828,8 @@ func (m *model) doAction(action *actions.Action) {
 		actionHandler = m.MoveCursorUp
 	case actions.OpenTile:
 		actionHandler = m.OpenTile
+	case actions.ChordTile:
+		actionHandler = m.ChordTile
 	case actions.MoveCursorToBottomRow:
 		actionHandler = m.MoveCursorToBottomRow
 	case actions.MoveCursorToTopRow:
@@ -363,94 +838,427 @@ func (m *model) doAction(action *actions.Action) {
 		actionHandler = m.MoveCursorToFirstColumn
 	case actions.MoveCursorToLastColumn:
 		actionHandler = m.MoveCursorToLastColumn
+	case actions.MoveCursorToNextClosedTile:
+		actionHandler = m.MoveCursorToNextClosedTile
+	case actions.MoveCursorToPreviousClosedTile:
+		actionHandler = m.MoveCursorToPreviousClosedTile
+	case actions.MoveCursorToNextFrontierTile:
+		actionHandler = m.MoveCursorToNextFrontierTile
+	case actions.MoveCursorToPreviousFrontierTile:
+		actionHandler = m.MoveCursorToPreviousFrontierTile
+	case actions.MoveCursorToNextUnsatisfiedTile:
+		actionHandler = m.MoveCursorToNextUnsatisfiedTile
+	case actions.MoveCursorToPreviousUnsatisfiedTile:
+		actionHandler = m.MoveCursorToPreviousUnsatisfiedTile
+	case actions.ScrollUp:
+		actionHandler = m.ScrollUp
+	case actions.ScrollDown:
+		actionHandler = m.ScrollDown
+	case actions.ScrollLeft:
+		actionHandler = m.ScrollLeft
+	case actions.ScrollRight:
+		actionHandler = m.ScrollRight
+	case actions.CenterCursor:
+		actionHandler = m.CenterCursor
+	case actions.CycleDensity:
+		actionHandler = m.CycleDensity
+	case actions.ToggleMinimap:
+		actionHandler = m.ToggleMinimap
+	case actions.SetMark:
+		actionHandler = func(_ uint16) { m.SetMark(action.Register) }
+	case actions.JumpToMark:
+		actionHandler = func(_ uint16) { m.JumpToMark(action.Register) }
+	case actions.JumpBack:
+		actionHandler = m.JumpBack
+	case actions.JumpForward:
+		actionHandler = m.JumpForward
+	case actions.RecordMacro:
+		actionHandler = func(_ uint16) { m.RecordMacro(action.Register) }
+	case actions.CommandLine:
+		actionHandler = m.OpenCommandLine
+	case actions.Help:
+		actionHandler = m.ToggleHelp
+	case actions.Pause:
+		actionHandler = m.TogglePause
+	}
+	// The actions of the other screens have nothing to do in the game
+	if actionHandler == nil {
+		return
 	}
+	from := m.cursorPosition
 	actionHandler(quantifier)
+	if isJump(action.Kind) && m.cursorPosition != from {
+		m.jumps.Push(from)
+	}
+	if action.Kind.IsChange() {
+		m.moves = append(m.moves, saves.Move{Action: action.Kind, Position: from})
+		change := *action
+		m.lastChange = &change
+	}
+
+	if m.isMultiplayer() && (action.Kind == actions.OpenTile || action.Kind == actions.ChordTile) {
+		m.endTurn(openCount, explosionCount)
+	}
 }
 
+// The app shows the end screen once the game is over, a race keeps it beside the standings
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	game, ok := next.(model)
+	if !ok || !game.gameEngine.IsFinished() || game.isOverSent || game.race != nil {
+		return next, cmd
+	}
+	game.isOverSent = true
+	endScreen := game.getEndScreen()
+	return game, tea.Batch(cmd, func() tea.Msg { return navigation.GameOverMsg{EndScreen: endScreen} })
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The app catches it before the game, a race ends its program with it so the peer is closed
+	if _, ok := msg.(navigation.ExitMsg); ok {
+		return m, tea.Quit
+	}
+	if _, ok := msg.(navigation.RestartMsg); ok {
+		return m.restart()
+	}
+	if msg, ok := msg.(standingsMsg); ok {
+		m.standings = msg
+		m.resizeViewport()
+		return m, waitForStandings(m.race)
+	}
 	if m.gameEngine.IsFinished() {
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
-			switch msg.String() {
-			case "q", "ctrl+c":
-				os.Exit(0)
-			}
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			_, cmd := m.getEndScreen().Update(msg)
+			return m, cmd
 		}
-		return m, tea.Quit
+		return m, nil
 	}
 	switch msg := msg.(type) {
+	case replayTickMsg:
+		return m, m.replayMove()
+	case tickMsg:
+		if msg.generation != m.generation {
+			return m, nil
+		}
+		if m.hasTimeLimit() && m.getTimeLeft() <= 0 {
+			m.gameEngine.Forfeit()
+			m.finish()
+			m.reportProgress()
+			return m, nil
+		}
+		return m, tick(m.generation)
 	case tea.WindowSizeMsg:
 		m.screenWidth = msg.Width
+		m.screenHeight = msg.Height
+		m.resizeViewport()
 	case tea.KeyMsg:
+		if m.isTyping {
+			return m.updateCommandLine(msg)
+		}
+		if m.isHelpShown {
+			return m.updateHelp(msg)
+		}
 		msgString := msg.String()
+		if kind, ok := isGlobalKey(msgString); ok {
+			return m.takeGlobalAction(kind)
+		}
+		if m.isReplay {
+			return m, nil
+		}
+		m.message = ""
+
+		m.keyPressBuffer += msgString
+		m.keyPresses++
 
-		switch msgString {
-		case "ctrl+c", "q":
-			os.Exit(0)
-		case "esc":
+		if !actions.AnyBindingStartWith(m.keyPressBuffer) {
 			m.previousKeyPressBuffer = m.keyPressBuffer
 			m.keyPressBuffer = ""
 			return m, nil
 		}
-		m.keyPressBuffer += msgString
 
-		if !actions.AnyBindingStartWith(m.keyPressBuffer) {
+		// Like in VIM the binding recording the macro stops it when pressed without a register
+		if m.recordingRegister != 0 && actions.IsBinding(m.keyPressBuffer, actions.RecordMacro) {
 			m.previousKeyPressBuffer = m.keyPressBuffer
 			m.keyPressBuffer = ""
+			m.stopRecording()
 			return m, nil
 		}
 
+		// A binding that is the start of a longer one as well waits for the next key until the timeout
 		action, err := actions.GetAction(m.keyPressBuffer)
-		if err != nil {
+		if err != nil || actions.HasLongerBinding(m.keyPressBuffer) {
+			return m, m.waitForKeys()
+		}
+		if action.Kind.IsGlobal() {
+			return m.takeGlobalAction(action.Kind)
+		}
+		m.takeAction(action)
+	case keyTimeoutMsg:
+		if msg.keyPresses != m.keyPresses || m.keyPressBuffer == "" {
+			return m, nil
+		}
+		// The shorter binding is taken when the longer one was not finished in time
+		if action, err := actions.GetAction(m.keyPressBuffer); err == nil {
+			if action.Kind.IsGlobal() {
+				return m.takeGlobalAction(action.Kind)
+			}
+			m.takeAction(action)
 			return m, nil
 		}
-
 		m.previousKeyPressBuffer = m.keyPressBuffer
 		m.keyPressBuffer = ""
-
-		m.doAction(action)
+	case tea.MouseMsg:
+		// Like the keys the mouse is left to the command line and the help while they hide the field
+		if !m.isReplay && !m.isTyping && !m.isHelpShown {
+			m.handleMouse(msg)
+		}
 	}
 
 	return m, nil
 }
 
+// Quantifiers wait for the keys of the action as long as it takes like in VIM
+func isQuantifier(keyStrokes string) bool {
+	return !strings.HasPrefix(keyStrokes, "0") && strings.Trim(keyStrokes, "0123456789") == ""
+}
+
+func (m model) waitForKeys() tea.Cmd {
+	if isQuantifier(m.keyPressBuffer) {
+		return nil
+	}
+	timeoutlen := m.config.Timeoutlen
+	if timeoutlen == 0 {
+		timeoutlen = defaultTimeoutlen
+	}
+	keyPresses := m.keyPresses
+	return tea.Tick(time.Duration(timeoutlen)*time.Millisecond, func(_ time.Time) tea.Msg {
+		return keyTimeoutMsg{keyPresses}
+	})
+}
+
+// Takes the action the buffer resolved to and clears it
+func (m *model) takeAction(action *actions.Action) {
+	m.previousKeyPressBuffer = m.keyPressBuffer
+	m.keyPressBuffer = ""
+
+	m.record(*action)
+	m.act(action)
+	m.followCursor()
+}
+
+func (m *model) act(action *actions.Action) {
+	m.doAction(action)
+	if m.gameEngine.IsFinished() {
+		m.finish()
+	}
+	m.reportProgress()
+}
+
+// Maps a terminal cell back to the tile drawn there
+// The field is drawn below the border of the table, the header and the top border of the field
+func (m model) getTileAt(x, y int) (types.Position, bool) {
+	var header strings.Builder
+	m.renderHeader(&header)
+	top := 1 + lipgloss.Height(header.String()) + 1
+	left := 1 + m.getGutterWidth()
+
+	if x < left || y < top {
+		return types.Position{}, false
+	}
+	tileWidth, tileHeight := tilerenderer.GetTileSize(m.density)
+	column := (x - left) / tileWidth
+	row := (y - top) / tileHeight
+	if column >= int(m.viewport.Width) || row >= int(m.viewport.Height) {
+		return types.Position{}, false
+	}
+	column += int(m.viewport.Column)
+	row += int(m.viewport.Row)
+	return types.Position{X: uint16(column), Y: m.config.Height - 1 - uint16(row)}, true
+}
+
+// Any mouse event over the field moves the cursor, presses are mapped to actions by the bindings
+func (m *model) handleMouse(msg tea.MouseMsg) {
+	if msg.Action == tea.MouseActionRelease {
+		m.pressedButton = tea.MouseButtonNone
+		return
+	}
+
+	position, ok := m.getTileAt(msg.X, msg.Y)
+	if !ok || tea.MouseEvent(msg).IsWheel() {
+		return
+	}
+	m.cursorPosition = position
+	if msg.Action != tea.MouseActionPress {
+		return
+	}
+
+	kind, ok := actions.GetMouseAction(msg.String())
+	// Pressing the left and the right buttons together chords like in the classic game
+	isBothButtons := (m.pressedButton == tea.MouseButtonLeft && msg.Button == tea.MouseButtonRight) ||
+		(m.pressedButton == tea.MouseButtonRight && msg.Button == tea.MouseButtonLeft)
+	if isBothButtons {
+		kind, ok = actions.ChordTile, true
+	}
+	m.pressedButton = msg.Button
+
+	// Only the clicks scroll like the keys do, hovering by the edge would keep scrolling the field away
+	if ok {
+		m.act(&actions.Action{Kind: kind, Quantifier: 1})
+		m.followCursor()
+	}
+}
+
 func (m model) renderHeader(s *strings.Builder) {
-	header := styles.HeaderStyle.Render(fmt.Sprintf("%v %v/%v", misc.AppName, m.flags, m.config.Mines))
+	headerStr := fmt.Sprintf("%v %v/%v", misc.AppName, m.flags, m.config.Mines)
+	if m.config.Lives > 1 && !m.isMultiplayer() {
+		headerStr += fmt.Sprintf(" lives %v/%v", m.gameEngine.GetLives(), m.config.Lives)
+	}
+	header := styles.HeaderStyle.Render(headerStr)
 	s.WriteString(header)
+
+	if m.isMultiplayer() {
+		m.renderPlayers(s)
+	}
+}
+
+func (m model) renderPlayers(s *strings.Builder) {
+	for ix, player := range m.players {
+		var playerStr string
+		if player.lives == 0 {
+			playerStr = fmt.Sprintf("P%v out", ix+1)
+		} else if m.config.Lives > 1 {
+			playerStr = fmt.Sprintf("P%v %v (%v)", ix+1, player.score, player.lives)
+		} else {
+			playerStr = fmt.Sprintf("P%v %v", ix+1, player.score)
+		}
+		if ix == m.currentPlayer {
+			playerStr = ">" + playerStr
+		} else {
+			playerStr = " " + playerStr
+		}
+		s.WriteString(" " + styles.GetPlayerStyle(ix).Render(playerStr))
+	}
+}
+
+func (m model) getLooks() tilerenderer.Looks {
+	return tilerenderer.Looks{Fill: m.config.Fill, ASCII: m.config.ASCII}
 }
 
 func (m model) renderTiles(s *strings.Builder) {
+	_, tileHeight := tilerenderer.GetTileSize(m.density)
 	var lines strings.Builder
-	for row := range m.config.Height {
+	lines.WriteString("\n" + m.renderEdge(true))
+	for row := m.viewport.Row; row < m.viewport.Row+m.viewport.Height; row++ {
 		y := (m.config.Height - 1 - row)
-		var line string
-		for col := range m.config.Width {
+		rowLines := make([]string, tileHeight)
+		for col := m.viewport.Column; col < m.viewport.Column+m.viewport.Width; col++ {
 			x := col
 			isFocused := uint16(x) == m.cursorPosition.X && uint16(y) == m.cursorPosition.Y
 			tile, err := m.tiles.GetTile(types.Position{X: x, Y: y})
 			if err != nil {
 				panic(err)
 			}
-			renderedTile := tilerenderer.RenderTileByContent(tile, isFocused)
-			line += renderedTile
-		}
-		lines.WriteString("\n")
-		if row == 0 {
-			lines.WriteString(styles.BorderTop.Render(line))
-		} else if row == uint16(len(m.tiles)-1) {
-			lines.WriteString(styles.BorderBottom.Render(line))
-		} else {
-			lines.WriteString(line)
+			style := styles.GetTileStyle(tile)
+			if owner, ok := m.owners.GetOwner(types.Position{X: x, Y: y}); ok && m.isMultiplayer() {
+				style = styles.GetPlayerStyle(owner)
+			}
+			for ix, tileLine := range m.getLooks().RenderTileLines(tile, style, isFocused, m.density) {
+				rowLines[ix] += tileLine
+			}
+		}
+		for ix, line := range rowLines {
+			gutter := m.renderGutter(y)
+			if ix != tileHeight/2 {
+				gutter = strings.Repeat(" ", m.getGutterWidth())
+			}
+			lines.WriteString("\n" + gutter + line)
 		}
 	}
+	lines.WriteString("\n" + m.renderEdge(false))
+
+	s.WriteString(lines.String())
+	s.WriteRune('\n')
+}
+
+// Width of the visible part of the field in cells
+func (m model) getFieldWidth() int {
+	tileWidth, _ := tilerenderer.GetTileSize(m.density)
+	return int(m.viewport.Width) * tileWidth
+}
+
+// The gutter is a column left of the field with the marks set in each row
+func (m model) getGutterWidth() int {
+	if m.config.MarkGutter {
+		return 1
+	}
+	return 0
+}
+
+// Shows the first mark set in the row
+func (m model) renderGutter(y uint16) string {
+	if !m.config.MarkGutter {
+		return ""
+	}
+	var registers []rune
+	for register, position := range m.marks {
+		if position.Y == y {
+			registers = append(registers, register)
+		}
+	}
+	if len(registers) == 0 {
+		return " "
+	}
+	return styles.HeaderStyle.Render(string(slices.Min(registers)))
+}
+
+// Draws the border above or below the field
+// Arrows show where the field continues past the edge of the screen
+func (m model) renderEdge(isTop bool) string {
+	width := m.getFieldWidth()
+	edge := []rune(strings.Repeat(lipgloss.RoundedBorder().Top, width))
+
+	if isTop && m.viewport.HasMoreAbove() {
+		edge[width/2] = '▲'
+	}
+	if !isTop && m.viewport.HasMoreBelow() {
+		edge[width/2] = '▼'
+	}
+	if m.viewport.HasMoreLeft() {
+		edge[0] = '◀'
+	}
+	if m.viewport.HasMoreRight() {
+		edge[width-1] = '▶'
+	}
+	return strings.Repeat(" ", m.getGutterWidth()) + string(edge)
+}
+
+// Hides the field so it could not be studied while the timer is stopped
+func (m model) renderPauseScreen(s *strings.Builder) {
+	var lines strings.Builder
+	width := m.getFieldWidth()
+	_, tileHeight := tilerenderer.GetTileSize(m.density)
+	height := int(m.viewport.Height) * tileHeight
+	lines.WriteString("\n" + m.renderEdge(true))
+	for row := range height {
+		line := styles.Center(width, "")
+		if row == height/2 {
+			line = styles.Center(width, styles.HeaderStyle.Render("paused"))
+		}
+		lines.WriteString("\n" + strings.Repeat(" ", m.getGutterWidth()) + line)
+	}
+	lines.WriteString("\n" + m.renderEdge(false))
 
 	s.WriteString(lines.String())
 	s.WriteRune('\n')
 }
 
 func (m model) renderFooter(s *strings.Builder) {
-	formattedDuration := utils.FormatTime(time.Since(m.startTime))
-	timeStr := fmt.Sprintf("%v", formattedDuration)
+	var timeStr string
+	if m.hasTimeLimit() {
+		timeStr = utils.FormatTime(max(m.getTimeLeft(), 0))
+	} else {
+		timeStr = utils.FormatTime(m.getElapsed())
+	}
 
 	var keysStr string
 	if m.keyPressBuffer != "" {
@@ -458,24 +1266,74 @@ func (m model) renderFooter(s *strings.Builder) {
 	} else {
 		keysStr = m.previousKeyPressBuffer
 	}
+	if m.recordingRegister != 0 {
+		keysStr = strings.TrimSpace(fmt.Sprintf("recording @%c %v", m.recordingRegister, keysStr))
+	}
+
+	if m.isTyping {
+		s.WriteString(m.commandInput.View())
+		return
+	}
+	if m.message != "" {
+		if m.isErrorMessage {
+			s.WriteString(styles.WarningText.Render(m.message))
+		} else {
+			s.WriteString(m.message)
+		}
+		return
+	}
 
-	margin := int(m.gameEngine.GetWidth())*3 - len(timeStr)
+	margin := m.getGutterWidth() + m.getFieldWidth() - len(timeStr)
 
-	s.WriteString(timeStr + styles.MarginLeft(margin, keysStr))
+	renderedTime := timeStr
+	if m.hasTimeLimit() {
+		if m.getTimeLeft() <= timeWarningThreshold {
+			renderedTime = styles.WarningText.Render(timeStr)
+		} else {
+			renderedTime = styles.HeaderStyle.Render(timeStr)
+		}
+	}
+
+	s.WriteString(renderedTime + styles.MarginLeft(margin, keysStr))
 }
 
 func (m model) View() string {
+	if m.race != nil {
+		return styles.SideBySide(m.renderGame(), standings.RenderStandings(m.standings, m.race.GetID()))
+	}
+	return m.renderGame()
+}
+
+func (m model) renderGame() string {
 	if m.gameEngine.IsFinished() {
-		endscreen := endscreen.CreateModel(m.startTime, m.gameEngine)
-		return endscreen.View()
+		return m.getEndScreen().View()
+	}
+
+	if m.isHelpShown {
+		return m.renderHelp()
 	}
 
 	var s strings.Builder
 	m.renderHeader(&s)
 
-	m.renderTiles(&s)
+	if m.isPaused {
+		m.renderPauseScreen(&s)
+	} else {
+		m.renderTiles(&s)
+	}
 
 	m.renderFooter(&s)
 
-	return styles.TableStyle.Render(s.String())
+	game := styles.TableStyle.Render(s.String())
+	panels := []string{game}
+	if m.isMinimapShown() && !m.isPaused {
+		panels = append(panels, m.renderMinimap())
+	}
+	if m.keyPressBuffer != "" && !isQuantifier(m.keyPressBuffer) {
+		if completions := actions.GetCompletions(m.keyPressBuffer); len(completions) > 0 {
+			// The border of the popup takes two lines
+			panels = append(panels, whichkey.Render(completions, lipgloss.Height(game)-2))
+		}
+	}
+	return styles.SideBySide(panels...)
 }
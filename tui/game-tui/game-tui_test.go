@@ -0,0 +1,334 @@
+package gametui
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	config "sweep/config"
+	actions "sweep/shared/consts/actions"
+	envkeys "sweep/shared/consts/env-keys"
+	tilecontent "sweep/shared/consts/tile-content"
+	tiles "sweep/shared/consts/tiles"
+	types "sweep/shared/types"
+	paths "sweep/shared/vars/paths"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+func createTestGame(conf config.Config) model {
+	if conf.Seed == 0 {
+		conf.Seed = 42
+	}
+	return CreateModel(&conf)
+}
+
+func findTile(m model, tile types.Tile) (types.Position, bool) {
+	for y := range m.gameEngine.GetHeight() {
+		for x := range m.gameEngine.GetWidth() {
+			position := types.Position{X: x, Y: y}
+			if m.gameEngine.GetTile(position) == tile {
+				return position, true
+			}
+		}
+	}
+	return types.Position{}, false
+}
+
+func openAt(m *model, position types.Position) {
+	m.cursorPosition = position
+	m.doAction(&actions.Action{Kind: actions.OpenTile, Quantifier: 1})
+}
+
+func Test_EndTurn(t *testing.T) {
+	m := createTestGame(config.Config{Width: 9, Height: 9, Mines: 10, Players: 2, Lives: 2})
+
+	openAt(&m, m.cursorPosition)
+	if m.currentPlayer != 1 {
+		t.Fatalf("[Assertion failed] expected the turn to pass to P2 after opening a tile, got P%v", m.currentPlayer+1)
+	}
+	if m.players[0].score == 0 {
+		t.Errorf("[Assertion failed] expected P1 to score the opened tiles")
+	}
+
+	// Opening the open tile again opens nothing so the turn stays
+	openAt(&m, m.cursorPosition)
+	if m.currentPlayer != 1 {
+		t.Errorf("[Assertion failed] expected the turn to stay with P2 when nothing was opened, got P%v", m.currentPlayer+1)
+	}
+
+	for hit := 1; hit <= 2; hit++ {
+		mine, ok := findTile(m, tiles.ClosedMine)
+		if !ok {
+			t.Fatalf("[Assertion failed] expected a closed mine on the field")
+		}
+		openAt(&m, mine)
+		if lives := m.players[1].lives; lives != uint16(2-hit) {
+			t.Errorf("[Assertion failed] expected P2 to have %v lives after hitting %v mines, got %v", 2-hit, hit, lives)
+		}
+		if m.currentPlayer != 0 {
+			t.Errorf("[Assertion failed] expected the turn to pass to P1 after P2 hit a mine, got P%v", m.currentPlayer+1)
+		}
+
+		// P1 passes the turn back by opening a safe tile
+		if hit == 1 {
+			safe, ok := findTile(m, tiles.ClosedSafe)
+			if !ok {
+				t.Fatalf("[Assertion failed] expected a closed safe tile on the field")
+			}
+			openAt(&m, safe)
+		}
+	}
+
+	// P2 is out so P1 keeps the turn
+	safe, ok := findTile(m, tiles.ClosedSafe)
+	if !ok {
+		t.Fatalf("[Assertion failed] expected a closed safe tile on the field")
+	}
+	openAt(&m, safe)
+	if m.currentPlayer != 0 {
+		t.Errorf("[Assertion failed] expected the turn to skip P2 with no lives left, got P%v", m.currentPlayer+1)
+	}
+	if !slices.Equal(m.explosionOwners, []int{1, 1}) {
+		t.Errorf("[Assertion failed] expected both mines to be hit by P2, got %v", m.explosionOwners)
+	}
+}
+
+func Test_LostLives(t *testing.T) {
+	type TestCase struct {
+		conf     config.Config
+		expected string
+	}
+
+	testCases := []TestCase{
+		{conf: config.Config{Width: 9, Height: 9, Mines: 10}, expected: ""},
+		{conf: config.Config{Width: 9, Height: 9, Mines: 10, Lives: 2}, expected: "life #1 lost at"},
+		{conf: config.Config{Width: 9, Height: 9, Mines: 10, Players: 2}, expected: "P2 lost life #1 at"},
+	}
+
+	for n, testCase := range testCases {
+		m := createTestGame(testCase.conf)
+		openAt(&m, m.cursorPosition)
+		// P2 hits the mine in hot-seat
+		mine, _ := findTile(m, tiles.ClosedMine)
+		openAt(&m, mine)
+
+		view := m.getEndScreen().View()
+		if testCase.expected == "" && strings.Contains(view, "life #") {
+			t.Errorf("[Assertion failed] #%v\nexpected no lost lives with a single life, got:\n%v", n+1, view)
+		}
+		if testCase.expected != "" && !strings.Contains(view, testCase.expected) {
+			t.Errorf("[Assertion failed] #%v\nexpected \"%v\" in:\n%v", n+1, testCase.expected, view)
+		}
+	}
+}
+
+func Test_Tick(t *testing.T) {
+	replaced := createTestGame(config.Config{Width: 9, Height: 9, Mines: 10})
+	m := createTestGame(config.Config{Width: 9, Height: 9, Mines: 10})
+
+	if _, cmd := m.Update(tickMsg{replaced.generation}); cmd != nil {
+		t.Errorf("[Assertion failed] the tick of the replaced game should not be scheduled again")
+	}
+	if _, cmd := m.Update(tickMsg{m.generation}); cmd == nil {
+		t.Errorf("[Assertion failed] the tick of the game should schedule the next one")
+	}
+}
+
+// Commands validate the whole config so the default one of the repo is taken with its schema
+func loadDefaultConfig(t *testing.T) config.Config {
+	paths.ConfigSchemaPath = "../../config.schema.json"
+	configBin, err := os.ReadFile("../../config.default.json")
+	if err != nil {
+		t.Fatalf("[Assertion failed] could not read the default config: %v", err)
+	}
+	var conf config.Config
+	if err = json.Unmarshal(configBin, &conf); err != nil {
+		t.Fatalf("[Assertion failed] could not parse the default config: %v", err)
+	}
+	conf.Width, conf.Height, conf.Mines = 9, 9, 10
+	return conf
+}
+
+func Test_SetOption(t *testing.T) {
+	// The flags of the command are validated along with the ones of the program
+	args := os.Args
+	os.Args = args[:1]
+	t.Cleanup(func() { os.Args = args })
+
+	m := createTestGame(loadDefaultConfig(t))
+	other := createTestGame(loadDefaultConfig(t))
+
+	next, _ := m.runCommand("set lives 3")
+	m = next.(model)
+	next, _ = m.runCommand("set fill")
+	m = next.(model)
+
+	if m.sharedConfig.Lives != 3 {
+		t.Errorf("[Assertion failed] expected the next game to have 3 lives, got %v", m.sharedConfig.Lives)
+	}
+	if m.config.Lives == 3 {
+		t.Errorf("[Assertion failed] expected the lives of the current game to be left as they were")
+	}
+	if !m.config.Fill {
+		t.Errorf("[Assertion failed] expected the fill to change the current game right away")
+	}
+	if _, ok := os.LookupEnv(envkeys.Lives); ok {
+		t.Errorf("[Assertion failed] expected the option to be kept out of the environment")
+	}
+	if other.config.Fill || other.sharedConfig.Lives == 3 {
+		t.Errorf("[Assertion failed] expected the options of one game to be left out of another")
+	}
+}
+
+// Finds the first run of closed tiles in a row for the actions walking right
+func findClosedRun(m model, length uint16) (types.Position, bool) {
+	for y := range m.config.Height {
+		for x := uint16(0); x+length <= m.config.Width; x++ {
+			isClosed := true
+			for ix := range length {
+				tile, _ := m.tiles.GetTile(types.Position{X: x + ix, Y: y})
+				isClosed = isClosed && tile == tilecontent.Empty
+			}
+			if isClosed {
+				return types.Position{X: x, Y: y}, true
+			}
+		}
+	}
+	return types.Position{}, false
+}
+
+func Test_PlayMacro(t *testing.T) {
+	m := createTestGame(config.Config{Width: 9, Height: 9, Mines: 10})
+	openAt(&m, m.cursorPosition)
+
+	start, ok := findClosedRun(m, 4)
+	if !ok {
+		t.Fatalf("[Assertion failed] expected 4 closed tiles in a row on the field")
+	}
+	m.cursorPosition = start
+
+	m.RecordMacro('a')
+	m.takeAction(&actions.Action{Kind: actions.FlagTile, Quantifier: 1})
+	m.takeAction(&actions.Action{Kind: actions.MoveCursorRight, Quantifier: 1})
+	m.stopRecording()
+
+	m.takeAction(&actions.Action{Kind: actions.PlayMacro, Register: 'a', Quantifier: 3})
+	if m.flags != 4 {
+		t.Errorf("[Assertion failed] expected the macro played 3 times after recording to flag 4 tiles, got %v", m.flags)
+	}
+	if expected := (types.Position{X: start.X + 4, Y: start.Y}); m.cursorPosition != expected {
+		t.Errorf("[Assertion failed] expected the cursor at %v after the macro, got %v", expected, m.cursorPosition)
+	}
+
+	// The macro playing itself plays what was recorded so far but never goes into itself again
+	m.RecordMacro('b')
+	m.takeAction(&actions.Action{Kind: actions.MoveCursorLeft, Quantifier: 1})
+	m.takeAction(&actions.Action{Kind: actions.PlayMacro, Register: 'b', Quantifier: 1})
+	m.stopRecording()
+
+	m.takeAction(&actions.Action{Kind: actions.PlayMacro, Register: 'b', Quantifier: 1})
+	if expected := (types.Position{X: start.X + 1, Y: start.Y}); m.cursorPosition != expected {
+		t.Errorf("[Assertion failed] expected the macro to play itself only once at %v, got %v", expected, m.cursorPosition)
+	}
+}
+
+func Test_RepeatAction(t *testing.T) {
+	m := createTestGame(config.Config{Width: 9, Height: 9, Mines: 10})
+
+	m.takeAction(&actions.Action{Kind: actions.RepeatAction, Quantifier: 1})
+	if m.openedATile {
+		t.Fatalf("[Assertion failed] expected the dot to do nothing before any change")
+	}
+
+	openAt(&m, m.cursorPosition)
+	start, ok := findClosedRun(m, 2)
+	if !ok {
+		t.Fatalf("[Assertion failed] expected 2 closed tiles in a row on the field")
+	}
+	m.cursorPosition = start
+
+	m.takeAction(&actions.Action{Kind: actions.FlagTile, Quantifier: 1})
+	m.takeAction(&actions.Action{Kind: actions.MoveCursorRight, Quantifier: 1})
+	// The quantifier of the dot would toggle the flag back if it was taken
+	m.takeAction(&actions.Action{Kind: actions.RepeatAction, Quantifier: 2})
+	if m.flags != 2 {
+		t.Errorf("[Assertion failed] expected the dot to flag the tile under the cursor once, got %v flags", m.flags)
+	}
+	if tile, _ := m.tiles.GetTile(m.cursorPosition); tile != tilecontent.Flag {
+		t.Errorf("[Assertion failed] expected the tile under the cursor to be flagged, got %v", tile)
+	}
+
+	m.takeAction(&actions.Action{Kind: actions.RepeatAction, Quantifier: 1})
+	if m.flags != 1 {
+		t.Errorf("[Assertion failed] expected the dot to unflag the flagged tile, got %v flags", m.flags)
+	}
+}
+
+func pressKeys(m model, keys string) model {
+	for _, key := range keys {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		m = next.(model)
+	}
+	return m
+}
+
+func Test_ZeroKey(t *testing.T) {
+	actions.MoveCursorToFirstColumn.SetBinding("0")
+	actions.MoveCursorRight.SetBinding("l")
+	m := createTestGame(config.Config{Width: 20, Height: 9, Mines: 10})
+	m.cursorPosition.X = 5
+
+	m = pressKeys(m, "0")
+	if m.cursorPosition.X != 0 || m.keyPressBuffer != "" {
+		t.Errorf("[Assertion failed] expected 0 to move the cursor to the first column at once, got column %v and keys \"%v\"", m.cursorPosition.X, m.keyPressBuffer)
+	}
+
+	// The 0 after the first digit is a part of the quantifier
+	m = pressKeys(m, "10l")
+	if m.cursorPosition.X != 10 {
+		t.Errorf("[Assertion failed] expected 10l to move the cursor to column 10, got %v", m.cursorPosition.X)
+	}
+}
+
+func Test_ClickFollowsCursor(t *testing.T) {
+	actions.FlagTile.SetBinding("right press")
+	m := createTestGame(config.Config{Width: 40, Height: 40, Mines: 10, ScrollMargin: 2})
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 20})
+	m = next.(model)
+	if m.viewport.Height >= m.config.Height {
+		t.Fatalf("[Assertion failed] expected the field to be higher than the screen, got %v rows", m.viewport.Height)
+	}
+
+	// The bottom row of the screen is within the margin
+	bottom := m.viewport.Row + m.viewport.Height - 1
+	for y := range 20 {
+		position, ok := m.getTileAt(2, y)
+		if !ok || m.config.Height-1-position.Y != bottom {
+			continue
+		}
+		row := m.viewport.Row
+		next, _ = m.Update(tea.MouseMsg{X: 2, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonRight})
+		m = next.(model)
+		if m.viewport.Row == row {
+			t.Errorf("[Assertion failed] expected the click by the edge to scroll the field from row %v", row)
+		}
+		return
+	}
+	t.Fatalf("[Assertion failed] expected the bottom row of the field on the screen")
+}
+
+func Test_EndScreenFitsScreen(t *testing.T) {
+	m := createTestGame(config.Config{Width: 40, Height: 40, Mines: 10})
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 50, Height: 20})
+	m = next.(model)
+	m.gameEngine.Forfeit()
+
+	view := m.getEndScreen().View()
+	if width, height := lipgloss.Width(view), lipgloss.Height(view); width > 50 || height > 20 {
+		t.Errorf("[Assertion failed] expected the end screen to fit 50x20, got %vx%v:\n%v", width, height, view)
+	}
+}
@@ -0,0 +1,73 @@
+package gametui
+
+import (
+	"strings"
+
+	actions "sweep/shared/consts/actions"
+	help "sweep/tui/help"
+	styles "sweep/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const helpFooter = "scroll with the keys moving the cursor up and down, close with help or clear key buffer"
+
+// The help hides the field while the clock keeps going, the game has to be paused to stop it
+func (m *model) ToggleHelp(_ uint16) {
+	m.isHelpShown = !m.isHelpShown
+	m.helpOffset = 0
+}
+
+// Lines of the help are taken from the keymap every time so they are always the ones applied from the config
+func (m model) getHelpLines() []string {
+	return strings.Split(help.Render(actions.GetKeymap(), m.screenWidth-2), "\n")
+}
+
+// Lines of the help fitting on the screen between the borders and above the footer
+func (m model) getHelpHeight() int {
+	return max(m.screenHeight-3, 1)
+}
+
+func (m *model) scrollHelp(lines int) {
+	maxOffset := max(len(m.getHelpLines())-m.getHelpHeight(), 0)
+	m.helpOffset = min(max(m.helpOffset+lines, 0), maxOffset)
+}
+
+// Keys are taken one by one as the help has nothing to do with the key sequences
+func (m model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "down":
+		m.scrollHelp(1)
+		return m, nil
+	case "up":
+		m.scrollHelp(-1)
+		return m, nil
+	}
+
+	action, err := actions.GetAction(key)
+	if err != nil {
+		return m, nil
+	}
+	switch action.Kind {
+	case actions.Help, actions.ClearKeyBuffer:
+		m.ToggleHelp(1)
+	case actions.Quit, actions.RestartSameBoard, actions.NewGame, actions.MainMenu:
+		m.ToggleHelp(1)
+		return m.takeGlobalAction(action.Kind)
+	case actions.MoveCursorDown, actions.ScrollDown:
+		m.scrollHelp(1)
+	case actions.MoveCursorUp, actions.ScrollUp:
+		m.scrollHelp(-1)
+	}
+	return m, nil
+}
+
+func (m model) renderHelp() string {
+	lines := m.getHelpLines()
+	height := m.getHelpHeight()
+	end := min(m.helpOffset+height, len(lines))
+	visible := lines[min(m.helpOffset, end):end]
+
+	return styles.TableStyle.Render(strings.Join(visible, "\n") + "\n" + styles.DimText.Render(helpFooter))
+}
@@ -0,0 +1,59 @@
+package gametui
+
+import (
+	"slices"
+
+	actions "sweep/shared/consts/actions"
+)
+
+// Recording into a register drops the macro that was there before
+func (m *model) RecordMacro(register rune) {
+	m.recordingRegister = register
+	m.macros[register] = nil
+}
+
+func (m *model) stopRecording() {
+	m.recordingRegister = 0
+}
+
+// Keeps the action taken with the keys in the macro being recorded
+// Mouse clicks are not recorded as the cursor follows the mouse without any action
+func (m *model) record(action actions.Action) {
+	switch action.Kind {
+	case actions.RecordMacro, actions.CommandLine, actions.Help:
+		return
+	}
+	if m.recordingRegister != 0 {
+		m.macros[m.recordingRegister] = append(m.macros[m.recordingRegister], action)
+	}
+}
+
+// A macro playing another one is fine but a macro never plays itself so it could not loop forever
+func (m *model) PlayMacro(register rune, quantifier uint16) {
+	if slices.Contains(m.playingRegisters, register) {
+		return
+	}
+	m.playingRegisters = append(m.playingRegisters, register)
+	defer func() {
+		m.playingRegisters = m.playingRegisters[:len(m.playingRegisters)-1]
+	}()
+
+	macro := m.macros[register]
+	for range quantifier {
+		for _, action := range macro {
+			if m.gameEngine.IsFinished() {
+				return
+			}
+			m.doAction(&action)
+		}
+	}
+}
+
+// The changes take the tile under the cursor only so the dot takes no quantifier
+func (m *model) RepeatAction() {
+	if m.lastChange == nil {
+		return
+	}
+	action := *m.lastChange
+	m.doAction(&action)
+}
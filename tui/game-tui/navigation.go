@@ -0,0 +1,78 @@
+package gametui
+
+import (
+	"fmt"
+
+	actions "sweep/shared/consts/actions"
+	endscreen "sweep/tui/end-screen"
+	navigation "sweep/tui/navigation"
+	standings "sweep/tui/standings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+type ActionNotAvailableError struct {
+	action actions.ActionType
+}
+
+func (e *ActionNotAvailableError) Error() string {
+	return fmt.Sprintf("%v is not available in a race", e.action)
+}
+
+func (e *ActionNotAvailableError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+// Races are only left by quitting as every player has to play the same field to the end
+// Hot-seat games end with the scores of the players
+func (m model) getEndScreen() tea.Model {
+	endScreen := endscreen.CreateModel(m.duration, m.gameEngine, !m.usedFlags).
+		WithLooks(m.getLooks()).
+		WithLives(max(m.config.Lives, 1) * uint16(len(m.players))).
+		WithCursor(m.cursorPosition)
+	if m.race != nil {
+		width := m.screenWidth - lipgloss.Width(standings.RenderStandings(m.standings, m.race.GetID()))
+		return endScreen.WithSize(width, m.screenHeight).WithActions(actions.Quit)
+	}
+	endScreen = endScreen.WithSize(m.screenWidth, m.screenHeight)
+	if m.isMultiplayer() {
+		scores := make([]uint16, len(m.players))
+		for ix, player := range m.players {
+			scores[ix] = player.score
+		}
+		return endScreen.WithScores(scores, m.explosionOwners)
+	}
+	return endScreen
+}
+
+// Global actions are taken whatever was typed before their keys
+func (m model) takeGlobalAction(kind actions.ActionType) (tea.Model, tea.Cmd) {
+	m.previousKeyPressBuffer = m.keyPressBuffer
+	m.keyPressBuffer = ""
+	if kind == actions.ClearKeyBuffer {
+		return m, nil
+	}
+	if m.race != nil && kind != actions.Quit {
+		m.setMessage((&ActionNotAvailableError{kind}).Error(), true)
+		return m, nil
+	}
+	cmd, _ := navigation.FromAction(kind)
+	return m, cmd
+}
+
+// Tells if the key is taken at once for a global action rather than being added to the typed keys
+// A key starting a longer binding is left to the key sequences
+func isGlobalKey(key string) (actions.ActionType, bool) {
+	kind, ok := actions.GetBoundAction(key)
+	return kind, ok && kind.IsGlobal() && !actions.HasLongerBinding(key)
+}
+
+// Starts the same field over, the options set from the command line since then are left for the next games
+func (m model) restart() (tea.Model, tea.Cmd) {
+	next := m.config
+	next.Seed = m.seed
+	game := CreateModel(&next)
+	game.sharedConfig = m.sharedConfig
+	return m.replaceWith(game)
+}
@@ -0,0 +1,96 @@
+package gametui
+
+import (
+	"fmt"
+	"time"
+
+	config "sweep/config"
+	saves "sweep/saves"
+	actions "sweep/shared/consts/actions"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Time between the moves of a replay
+const replayInterval = 300 * time.Millisecond
+
+type replayTickMsg struct{}
+
+func waitForReplay() tea.Cmd {
+	return tea.Tick(replayInterval, func(_ time.Time) tea.Msg {
+		return replayTickMsg{}
+	})
+}
+
+// Creates the field of the save on its seed without any move taken
+// The options the game is created with are kept for the next games
+func createFromSave(conf *config.Config, save saves.Game) (model, error) {
+	next := *conf
+	next.Width = save.Width
+	next.Height = save.Height
+	next.Mines = save.Mines
+	next.Lives = save.Lives
+	next.Players = save.Players
+	next.TimeLimit = save.TimeLimit
+	next.TimeBonus = save.TimeBonus
+	next.WinCondition = save.WinCondition
+	next.NoFlag = save.NoFlag
+	next.Seed = save.Seed
+	if err := validateField(next); err != nil {
+		return model{}, err
+	}
+
+	game := CreateModel(&next)
+	game.sharedConfig = conf
+	return game, nil
+}
+
+// Replays the moves of the save on the same seed at once so the game goes on where it was left
+func CreateFromSave(conf *config.Config, save saves.Game) (model, error) {
+	game, err := createFromSave(conf, save)
+	if err != nil {
+		return game, err
+	}
+	for _, move := range save.Moves {
+		game.cursorPosition = move.Position
+		game.doAction(&actions.Action{Kind: move.Action, Quantifier: 1})
+	}
+	game.cursorPosition = save.Cursor
+	game.startTime = time.Now().Add(-save.Duration)
+	return game, nil
+}
+
+// Plays the moves of the save back one by one
+// Only the global actions are taken from the keys meanwhile and the game is not counted in the history
+func CreateReplay(conf *config.Config, save saves.Game) (model, error) {
+	game, err := createFromSave(conf, save)
+	if err != nil {
+		return game, err
+	}
+	game.isReplay = true
+	game.replay = save.Moves
+	return game, nil
+}
+
+func (m *model) replayMove() tea.Cmd {
+	if m.replayed >= len(m.replay) {
+		return nil
+	}
+	move := m.replay[m.replayed]
+	m.replayed++
+	m.cursorPosition = move.Position
+	m.act(&actions.Action{Kind: move.Action, Quantifier: 1})
+	m.followCursor()
+	m.setMessage(fmt.Sprintf("replay %v/%v", m.replayed, len(m.replay)), false)
+	return waitForReplay()
+}
+
+// Returns the save of the game while it is played so it could be continued later
+// Finished games, races and replays have nothing to continue
+func GetSave(game tea.Model) (saves.Game, bool) {
+	m, ok := game.(model)
+	if !ok || !m.openedATile || m.gameEngine.IsFinished() || m.race != nil || m.isReplay {
+		return saves.Game{}, false
+	}
+	return m.getSave(), true
+}
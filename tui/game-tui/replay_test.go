@@ -0,0 +1,111 @@
+package gametui
+
+import (
+	"testing"
+
+	config "sweep/config"
+	saves "sweep/saves"
+	actions "sweep/shared/consts/actions"
+	types "sweep/shared/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func Test_CreateFromSave(t *testing.T) {
+	conf := config.Config{Width: 9, Height: 9, Mines: 10, Seed: 42}
+	m := createTestGame(conf)
+	openAt(&m, m.cursorPosition)
+
+	start, ok := findClosedRun(m, 2)
+	if !ok {
+		t.Fatalf("[Assertion failed] expected 2 closed tiles in a row on the field")
+	}
+	m.cursorPosition = start
+	m.takeAction(&actions.Action{Kind: actions.FlagTile, Quantifier: 1})
+	m.takeAction(&actions.Action{Kind: actions.MoveCursorRight, Quantifier: 1})
+
+	save, ok := GetSave(m)
+	if !ok {
+		t.Fatalf("[Assertion failed] expected the game being played to be saved")
+	}
+	continued, err := CreateFromSave(&conf, save)
+	if err != nil {
+		t.Fatalf("[Assertion failed] could not continue the save: %v", err)
+	}
+
+	if opened, expected := continued.gameEngine.GetOpenCount(), m.gameEngine.GetOpenCount(); opened != expected {
+		t.Errorf("[Assertion failed] expected %v open tiles, got %v", expected, opened)
+	}
+	if continued.flags != m.flags {
+		t.Errorf("[Assertion failed] expected %v flags, got %v", m.flags, continued.flags)
+	}
+	if continued.cursorPosition != m.cursorPosition {
+		t.Errorf("[Assertion failed] expected the cursor at %v, got %v", m.cursorPosition, continued.cursorPosition)
+	}
+	for y := range conf.Height {
+		for x := range conf.Width {
+			position := types.Position{X: x, Y: y}
+			tile, _ := continued.tiles.GetTile(position)
+			expected, _ := m.tiles.GetTile(position)
+			if tile != expected {
+				t.Errorf("[Assertion failed] expected %v at %v, got %v", expected, position, tile)
+			}
+		}
+	}
+}
+
+func Test_GetSave(t *testing.T) {
+	conf := config.Config{Width: 9, Height: 9, Mines: 10, Seed: 42}
+
+	m := createTestGame(conf)
+	if _, ok := GetSave(m); ok {
+		t.Errorf("[Assertion failed] expected the game with no open tiles not to be saved")
+	}
+
+	openAt(&m, m.cursorPosition)
+	save, ok := GetSave(m)
+	if !ok {
+		t.Fatalf("[Assertion failed] expected the game being played to be saved")
+	}
+
+	replay, err := CreateReplay(&conf, save)
+	if err != nil {
+		t.Fatalf("[Assertion failed] could not replay the save: %v", err)
+	}
+	replay.replayMove()
+	if _, ok := GetSave(replay); ok {
+		t.Errorf("[Assertion failed] expected the replay not to be saved")
+	}
+
+	m.gameEngine.Forfeit()
+	if _, ok := GetSave(m); ok {
+		t.Errorf("[Assertion failed] expected the finished game not to be saved")
+	}
+
+	var other tea.Model = struct{ tea.Model }{}
+	if _, ok := GetSave(other); ok {
+		t.Errorf("[Assertion failed] expected the other screens not to be saved")
+	}
+}
+
+func Test_CreateFromSaveUnknownMove(t *testing.T) {
+	conf := config.Config{Width: 9, Height: 9, Mines: 10, Seed: 42}
+	save := saves.Game{
+		Width:  9,
+		Height: 9,
+		Mines:  10,
+		Seed:   42,
+		Moves: []saves.Move{
+			{Action: actions.OpenTile, Position: types.Position{X: 4, Y: 4}},
+			{Action: actions.Quit, Position: types.Position{X: 4, Y: 4}},
+		},
+	}
+
+	continued, err := CreateFromSave(&conf, save)
+	if err != nil {
+		t.Fatalf("[Assertion failed] could not continue the save: %v", err)
+	}
+	if continued.gameEngine.GetOpenCount() == 0 {
+		t.Errorf("[Assertion failed] expected the moves before the unknown one to be taken")
+	}
+}
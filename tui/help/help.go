@@ -0,0 +1,121 @@
+package help
+
+import (
+	"fmt"
+	"strings"
+
+	actions "sweep/shared/consts/actions"
+	styles "sweep/tui/styles"
+
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+type row struct {
+	mark        string
+	keys        string
+	description string
+}
+
+type group struct {
+	title string
+	kinds []actions.ActionType
+}
+
+var groups = []group{
+	{
+		title: "Movement",
+		kinds: []actions.ActionType{
+			actions.MoveCursorUp, actions.MoveCursorDown, actions.MoveCursorLeft, actions.MoveCursorRight,
+			actions.MoveCursorToTopRow, actions.MoveCursorToBottomRow,
+			actions.MoveCursorToFirstColumn, actions.MoveCursorToLastColumn,
+			actions.MoveCursorToNextClosedTile, actions.MoveCursorToPreviousClosedTile,
+			actions.MoveCursorToNextFrontierTile, actions.MoveCursorToPreviousFrontierTile,
+			actions.MoveCursorToNextUnsatisfiedTile, actions.MoveCursorToPreviousUnsatisfiedTile,
+			actions.SetMark, actions.JumpToMark, actions.JumpBack, actions.JumpForward,
+			actions.ScrollUp, actions.ScrollDown, actions.ScrollLeft, actions.ScrollRight,
+			actions.CenterCursor,
+		},
+	},
+	{
+		title: "Actions",
+		kinds: []actions.ActionType{
+			actions.OpenTile, actions.FlagTile, actions.ChordTile,
+			actions.RecordMacro, actions.PlayMacro, actions.RepeatAction,
+		},
+	},
+	{
+		title: "Commands",
+		kinds: []actions.ActionType{
+			actions.CommandLine, actions.Pause, actions.CycleDensity, actions.ToggleMinimap, actions.Help,
+			actions.ClearKeyBuffer, actions.RestartSameBoard, actions.NewGame, actions.MainMenu, actions.Quit,
+		},
+	},
+}
+
+const (
+	// Marks the actions taking a quantifier
+	quantifierMark = "N"
+	unboundKeys    = "-"
+)
+
+var quantifierHint = []string{
+	quantifierMark + " marks the actions taking a quantifier,",
+	"a number typed before their keys:",
+	"5j moves the cursor 5 tiles and 3@a plays the macro a 3 times",
+}
+
+// Groups the keys bound to every action in the keymap
+func getKeys(keymap []actions.Binding) map[actions.ActionType][]string {
+	keys := map[actions.ActionType][]string{}
+	for _, binding := range keymap {
+		keys[binding.Kind] = append(keys[binding.Kind], binding.Keys)
+	}
+	return keys
+}
+
+func renderGroup(g group, keys map[actions.ActionType][]string) string {
+	var rows []row
+	for _, kind := range g.kinds {
+		r := row{keys: strings.Join(keys[kind], ", "), description: string(kind)}
+		if r.keys == "" {
+			r.keys = unboundKeys
+		}
+		if kind.IsQuantifiable() {
+			r.mark = quantifierMark
+		}
+		rows = append(rows, r)
+	}
+
+	keysWidth := 0
+	for _, r := range rows {
+		keysWidth = max(keysWidth, lipgloss.Width(r.keys))
+	}
+
+	lines := []string{styles.HeaderStyle.Render(g.title)}
+	for _, r := range rows {
+		mark := styles.DimText.Render(fmt.Sprintf("%1v", r.mark))
+		keys := styles.HeaderStyle.Render(fmt.Sprintf("%-*v", keysWidth, r.keys))
+		lines = append(lines, fmt.Sprintf("%v %v  %v", mark, keys, r.description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Lists every action with the keys bound to it in the keymap as it was applied from the config
+// The groups are placed side by side when they fit in the width and one under another otherwise
+func Render(keymap []actions.Binding, width int) string {
+	keys := getKeys(keymap)
+
+	panels := make([]string, len(groups))
+	for ix, g := range groups {
+		panels[ix] = renderGroup(g, keys)
+		if ix < len(groups)-1 {
+			panels[ix] = lipgloss.NewStyle().PaddingRight(2).Render(panels[ix])
+		}
+	}
+
+	body := styles.SideBySide(panels...)
+	if lipgloss.Width(body) > width {
+		body = strings.Join(panels, "\n\n")
+	}
+	return body + "\n\n" + styles.DimText.Render(strings.Join(quantifierHint, "\n"))
+}
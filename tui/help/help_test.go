@@ -0,0 +1,56 @@
+package help
+
+import (
+	"strings"
+	"testing"
+
+	actions "sweep/shared/consts/actions"
+)
+
+func Test_Groups(t *testing.T) {
+	// Every action has to be listed in the help exactly once
+	counts := map[actions.ActionType]int{}
+	for _, g := range groups {
+		for _, kind := range g.kinds {
+			counts[kind]++
+		}
+	}
+	for _, kind := range actions.GetActions() {
+		if counts[kind] != 1 {
+			t.Errorf("[Assertion failed] %v\nexpected to be listed once, actual: %v times", kind, counts[kind])
+		}
+		delete(counts, kind)
+	}
+	for kind := range counts {
+		t.Errorf("[Assertion failed] %v is listed but is not an action", kind)
+	}
+}
+
+func Test_Render(t *testing.T) {
+	type TestCase struct {
+		width    int
+		expected string
+	}
+
+	keymap := []actions.Binding{
+		{Keys: "j", Kind: actions.MoveCursorDown},
+		{Keys: "s", Kind: actions.MoveCursorDown},
+		{Keys: "m{a-z}", Kind: actions.SetMark},
+		{Keys: "ctrl+c", Kind: actions.Quit},
+	}
+
+	testCases := []TestCase{
+		{width: 200, expected: "j, s"},
+		{width: 200, expected: "m{a-z}"},
+		{width: 200, expected: "-"},
+		{width: 200, expected: "ctrl+c"},
+		{width: 200, expected: "5j moves the cursor"},
+		{width: 20, expected: "j, s"},
+	}
+
+	for n, testCase := range testCases {
+		if rendered := Render(keymap, testCase.width); !strings.Contains(rendered, testCase.expected) {
+			t.Errorf("[Assertion failed] #%v\nexpected to contain: %v\nactual:\n%v", n+1, testCase.expected, rendered)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package jumplist
+
+import types "sweep/shared/types"
+
+// Keeps the cursor positions left by large jumps like the VIM jump list
+type JumpList struct {
+	positions []types.Position
+	// Points past the last position unless the list is being walked through
+	index int
+}
+
+// Drops the positions ahead of the current one like VIM does when jumping from the middle of the list
+func (l *JumpList) Push(position types.Position) {
+	l.positions = append(l.positions[:l.index], position)
+	l.index = len(l.positions)
+}
+
+// Takes the current position so it could be returned to with Forward
+func (l *JumpList) Back(current types.Position) (types.Position, bool) {
+	if l.index == 0 {
+		return current, false
+	}
+	if l.index == len(l.positions) {
+		l.positions = append(l.positions, current)
+	}
+	l.index--
+	return l.positions[l.index], true
+}
+
+func (l *JumpList) Forward() (types.Position, bool) {
+	if l.index >= len(l.positions)-1 {
+		return types.Position{}, false
+	}
+	l.index++
+	return l.positions[l.index], true
+}
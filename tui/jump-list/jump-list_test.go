@@ -0,0 +1,45 @@
+package jumplist
+
+import (
+	"testing"
+
+	types "sweep/shared/types"
+)
+
+func Test_JumpList(t *testing.T) {
+	var jumps JumpList
+	a, b, c := types.Position{X: 1}, types.Position{X: 2}, types.Position{X: 3}
+
+	if _, ok := jumps.Back(a); ok {
+		t.Errorf("[Assertion failed] empty jump list should not go back")
+	}
+
+	jumps.Push(a)
+	jumps.Push(b)
+
+	if position, ok := jumps.Back(c); !ok || position != b {
+		t.Errorf("[Assertion failed] expected to go back to %v, got %v", b, position)
+	}
+	if position, ok := jumps.Back(b); !ok || position != a {
+		t.Errorf("[Assertion failed] expected to go back to %v, got %v", a, position)
+	}
+	if _, ok := jumps.Back(a); ok {
+		t.Errorf("[Assertion failed] should not go back past the first jump")
+	}
+	if position, ok := jumps.Forward(); !ok || position != b {
+		t.Errorf("[Assertion failed] expected to go forward to %v, got %v", b, position)
+	}
+	if position, ok := jumps.Forward(); !ok || position != c {
+		t.Errorf("[Assertion failed] expected to go forward to the position before going back %v, got %v", c, position)
+	}
+	if _, ok := jumps.Forward(); ok {
+		t.Errorf("[Assertion failed] should not go forward past the last position")
+	}
+
+	// Jumping from the middle of the list drops the positions ahead
+	jumps.Back(c)
+	jumps.Push(a)
+	if _, ok := jumps.Forward(); ok {
+		t.Errorf("[Assertion failed] positions ahead should be dropped after a new jump")
+	}
+}
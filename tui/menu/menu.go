@@ -0,0 +1,167 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	actions "sweep/shared/consts/actions"
+	misc "sweep/shared/consts/misc"
+	navigation "sweep/tui/navigation"
+	styles "sweep/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+const cursor = "> "
+
+// An item is either taken with enter or holds a value changed with enter and the arrows
+type Item struct {
+	Title string
+	Cmd   tea.Cmd
+	Value func() string
+	// Steps the value by 1 forward or backward
+	Change func(step int)
+}
+
+type model struct {
+	title    string
+	body     string
+	items    []Item
+	selected int
+	// The main menu has nowhere to go back to
+	isMain bool
+}
+
+var _ tea.Model = model{}
+
+func CreateModel(title string, items []Item) model {
+	return model{
+		title: title,
+		items: items,
+	}
+}
+
+// Shows the text between the title and the items
+func (m model) WithBody(body string) model {
+	m.body = body
+	return m
+}
+
+// Lists the screens of the app, the game left on quitting is continued while it is saved
+func CreateMainMenu(canContinue bool) model {
+	var items []Item
+	if canContinue {
+		items = append(items, Item{Title: "continue", Cmd: func() tea.Msg { return navigation.ContinueMsg{} }})
+	}
+	items = append(items,
+		Item{Title: "new game", Cmd: navigation.Show(navigation.StartScreen)},
+		Item{Title: "replays", Cmd: navigation.Show(navigation.ReplaysScreen)},
+		Item{Title: "stats", Cmd: navigation.Show(navigation.StatsScreen)},
+		Item{Title: "settings", Cmd: navigation.Show(navigation.SettingsScreen)},
+		Item{Title: "quit", Cmd: navigation.Exit},
+	)
+	m := CreateModel(misc.AppAsciiLogo, items)
+	m.isMain = true
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.SetWindowTitle(misc.AppName)
+}
+
+func (m *model) move(step int) {
+	if len(m.items) == 0 {
+		return
+	}
+	m.selected = (m.selected + step + len(m.items)) % len(m.items)
+}
+
+func (m model) change(step int) {
+	if len(m.items) == 0 {
+		return
+	}
+	if item := m.items[m.selected]; item.Change != nil {
+		item.Change(step)
+	}
+}
+
+func (m model) choose() tea.Cmd {
+	if len(m.items) == 0 {
+		return nil
+	}
+	item := m.items[m.selected]
+	if item.Change != nil {
+		item.Change(1)
+	}
+	return item.Cmd
+}
+
+// The arrows, tab and enter are fixed like on the start screen, the rest is taken from the bindings
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	key := keyMsg.String()
+	switch key {
+	case "up", "shift+tab":
+		m.move(-1)
+		return m, nil
+	case "down", "tab":
+		m.move(1)
+		return m, nil
+	case "left":
+		m.change(-1)
+		return m, nil
+	case "right":
+		m.change(1)
+		return m, nil
+	case "enter":
+		return m, m.choose()
+	}
+
+	kind, _ := actions.GetScreenAction(actions.MenuContext, key)
+	switch kind {
+	case actions.Quit:
+		return m, navigation.Exit
+	case actions.MainMenu, actions.ClearKeyBuffer:
+		if !m.isMain {
+			return m, navigation.MainMenu
+		}
+	case actions.MoveCursorUp:
+		m.move(-1)
+	case actions.MoveCursorDown:
+		m.move(1)
+	}
+	return m, nil
+}
+
+func (m model) View() string {
+	var s strings.Builder
+	s.WriteString(styles.HeaderStyle.Render(m.title))
+	s.WriteString("\n\n")
+	if m.body != "" {
+		s.WriteString(m.body)
+		s.WriteString("\n\n")
+	}
+
+	titleWidth := 0
+	for _, item := range m.items {
+		titleWidth = max(titleWidth, lipgloss.Width(item.Title))
+	}
+	for ix, item := range m.items {
+		line := item.Title
+		if item.Value != nil {
+			line = fmt.Sprintf("%-*v  < %v >", titleWidth, item.Title, item.Value())
+		}
+		if ix == m.selected {
+			s.WriteString(styles.BrightText.Render(cursor + line))
+		} else {
+			s.WriteString(styles.DimText.Render(strings.Repeat(" ", len(cursor)) + line))
+		}
+		s.WriteRune('\n')
+	}
+	return s.String()
+}
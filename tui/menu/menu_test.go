@@ -0,0 +1,42 @@
+package menu
+
+import (
+	"testing"
+
+	navigation "sweep/tui/navigation"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func press(m tea.Model, keyType tea.KeyType) (tea.Model, tea.Cmd) {
+	return m.Update(tea.KeyMsg{Type: keyType})
+}
+
+func Test_Menu(t *testing.T) {
+	value := 0
+	items := []Item{
+		{Title: "value", Value: func() string { return "" }, Change: func(step int) { value += step }},
+		{Title: "back", Cmd: navigation.MainMenu},
+	}
+	var m tea.Model = CreateModel("menu", items)
+
+	m, _ = press(m, tea.KeyRight)
+	m, _ = press(m, tea.KeyRight)
+	m, _ = press(m, tea.KeyLeft)
+	if value != 1 {
+		t.Errorf("[Assertion failed] expected the arrows to change the value to 1, got %v", value)
+	}
+
+	m, _ = press(m, tea.KeyUp)
+	if selected := m.(model).selected; selected != 1 {
+		t.Errorf("[Assertion failed] expected moving up from the first item to wrap to the last one, got %v", selected)
+	}
+
+	_, cmd := press(m, tea.KeyEnter)
+	if cmd == nil {
+		t.Fatalf("[Assertion failed] expected enter to take the item")
+	}
+	if _, ok := cmd().(navigation.MainMenuMsg); !ok {
+		t.Errorf("[Assertion failed] expected enter to return the command of the item")
+	}
+}
@@ -0,0 +1,105 @@
+package minimap
+
+import (
+	"strings"
+
+	tilecontent "sweep/shared/consts/tile-content"
+	styles "sweep/tui/styles"
+	viewport "sweep/tui/viewport"
+)
+
+// Summary of a square block of tiles shown as a single cell
+type Block int
+
+const (
+	Unopened Block = iota
+	Opened
+	// Only flags among unopened tiles
+	Flagged
+	// Both opened and unopened tiles
+	Mixed
+)
+
+var blockGlyphs = map[Block]string{
+	Unopened: "#",
+	Opened:   ".",
+	Flagged:  "F",
+	Mixed:    "+",
+}
+
+// Returns how many tiles on each side a cell takes for the field to fit into the size
+func GetScale(fieldWidth, fieldHeight, size uint16) uint16 {
+	longestSide := max(fieldWidth, fieldHeight)
+	size = max(size, 1)
+	return max((longestSide+size-1)/size, 1)
+}
+
+// Takes the tiles indexed by the row from the bottom like the game does
+// Returns the blocks indexed by the row from the top like they are drawn
+func Summarize(tiles [][]tilecontent.TileContent, scale uint16) [][]Block {
+	height := uint16(len(tiles))
+	if height == 0 {
+		return [][]Block{}
+	}
+	width := uint16(len(tiles[0]))
+	scale = max(scale, 1)
+
+	blocks := make([][]Block, (height+scale-1)/scale)
+	for blockRow := range blocks {
+		blocks[blockRow] = make([]Block, (width+scale-1)/scale)
+		for blockColumn := range blocks[blockRow] {
+			var opened, unopened, flagged bool
+			for row := uint16(blockRow) * scale; row < min(uint16(blockRow+1)*scale, height); row++ {
+				for column := uint16(blockColumn) * scale; column < min(uint16(blockColumn+1)*scale, width); column++ {
+					switch tiles[height-1-row][column] {
+					case tilecontent.Empty:
+						unopened = true
+					case tilecontent.Flag:
+						flagged = true
+					default:
+						opened = true
+					}
+				}
+			}
+			switch {
+			case opened && (unopened || flagged):
+				blocks[blockRow][blockColumn] = Mixed
+			case opened:
+				blocks[blockRow][blockColumn] = Opened
+			case flagged:
+				blocks[blockRow][blockColumn] = Flagged
+			default:
+				blocks[blockRow][blockColumn] = Unopened
+			}
+		}
+	}
+	return blocks
+}
+
+// Draws the blocks with the visible part of the field highlighted and the cursor reversed
+// The cursor column and row are counted from the top left corner like in the viewport
+func Render(tiles [][]tilecontent.TileContent, scale uint16, view viewport.Viewport, cursorColumn, cursorRow uint16) string {
+	scale = max(scale, 1)
+	var lines []string
+	for blockRow, row := range Summarize(tiles, scale) {
+		var line strings.Builder
+		top := uint16(blockRow) * scale
+		for blockColumn, block := range row {
+			left := uint16(blockColumn) * scale
+
+			isVisible := left < view.Column+view.Width && left+scale > view.Column &&
+				top < view.Row+view.Height && top+scale > view.Row
+			hasCursor := cursorColumn/scale == uint16(blockColumn) && cursorRow/scale == uint16(blockRow)
+
+			style := styles.DimText
+			if hasCursor {
+				style = styles.HeaderStyle.Reverse(true)
+			} else if isVisible {
+				style = styles.HeaderStyle
+			}
+			line.WriteString(style.Render(blockGlyphs[block]))
+		}
+		lines = append(lines, line.String())
+	}
+	return styles.TableStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,63 @@
+package minimap
+
+import (
+	"testing"
+
+	tilecontent "sweep/shared/consts/tile-content"
+)
+
+func Test_GetScale(t *testing.T) {
+	type TestCase struct {
+		width, height, size uint16
+		expected            uint16
+	}
+
+	testCases := []TestCase{
+		{width: 200, height: 100, size: 20, expected: 10},
+		{width: 30, height: 70, size: 20, expected: 4},
+		{width: 10, height: 10, size: 20, expected: 1},
+		{width: 10, height: 10, size: 0, expected: 10},
+	}
+
+	for _, testCase := range testCases {
+		got := GetScale(testCase.width, testCase.height, testCase.size)
+		if got != testCase.expected {
+			t.Errorf("[Assertion failed] %vx%v in %v: expected scale %v, got %v",
+				testCase.width, testCase.height, testCase.size, testCase.expected, got)
+		}
+	}
+}
+
+func Test_Summarize(t *testing.T) {
+	e, f, o := tilecontent.Empty, tilecontent.Flag, tilecontent.One
+	// Rows are indexed from the bottom, so the last row here is drawn at the top
+	tiles := [][]tilecontent.TileContent{
+		{o, o, e, f, o},
+		{o, o, e, e, e},
+		{e, e, f, e, e},
+		{e, e, e, f, e},
+	}
+
+	expected := [][]Block{
+		{Unopened, Flagged, Unopened},
+		{Opened, Flagged, Mixed},
+	}
+
+	got := Summarize(tiles, 2)
+	if len(got) != len(expected) {
+		t.Fatalf("[Assertion failed] expected %v rows, got %v", len(expected), len(got))
+	}
+	for row := range expected {
+		for column := range expected[row] {
+			if got[row][column] != expected[row][column] {
+				t.Errorf("[Assertion failed] block %v:%v expected %v, got %v",
+					column, row, expected[row][column], got[row][column])
+			}
+		}
+	}
+
+	opened := Summarize([][]tilecontent.TileContent{{o, tilecontent.Mine}}, 2)
+	if opened[0][0] != Opened {
+		t.Errorf("[Assertion failed] expected open tiles and exploded mines to be opened, got %v", opened[0][0])
+	}
+}
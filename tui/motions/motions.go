@@ -0,0 +1,100 @@
+package motions
+
+import (
+	tilecontent "sweep/shared/consts/tile-content"
+	types "sweep/shared/types"
+)
+
+// Tiles as the player sees them indexed by the row from the bottom and the column
+type Tiles = [][]tilecontent.TileContent
+
+// Returns the next closed tile in the row of the position
+// Flagged tiles are skipped as there is nothing left to do with them
+func NextClosedInRow(tiles Tiles, position types.Position, forward bool) (types.Position, bool) {
+	row := tiles[position.Y]
+	step := 1
+	if !forward {
+		step = -1
+	}
+	for x := int(position.X) + step; x >= 0 && x < len(row); x += step {
+		if row[x] == tilecontent.Empty {
+			return types.Position{X: uint16(x), Y: position.Y}, true
+		}
+	}
+	return position, false
+}
+
+// Returns the next closed tile next to an open number
+func NextFrontier(tiles Tiles, position types.Position, forward bool) (types.Position, bool) {
+	return search(tiles, position, forward, func(candidate types.Position) bool {
+		if getTile(tiles, candidate) != tilecontent.Empty {
+			return false
+		}
+		for _, neighbour := range getNeighbours(tiles, candidate) {
+			if count, err := getTile(tiles, neighbour).ToNumber(); err == nil && count > 0 {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Returns the next open number which does not have as many flags around it as it shows
+func NextUnsatisfied(tiles Tiles, position types.Position, forward bool) (types.Position, bool) {
+	return search(tiles, position, forward, func(candidate types.Position) bool {
+		count, err := getTile(tiles, candidate).ToNumber()
+		if err != nil || count == 0 {
+			return false
+		}
+		var flags byte
+		for _, neighbour := range getNeighbours(tiles, candidate) {
+			// Exploded mines are as good as flagged ones
+			switch getTile(tiles, neighbour) {
+			case tilecontent.Flag, tilecontent.Mine:
+				flags++
+			}
+		}
+		return flags != count
+	})
+}
+
+// Walks the field in the reading order from the top left corner wrapping around like VIM search does
+func search(tiles Tiles, position types.Position, forward bool, matches func(types.Position) bool) (types.Position, bool) {
+	height := len(tiles)
+	if height == 0 {
+		return position, false
+	}
+	width := len(tiles[0])
+	total := width * height
+	start := (height-1-int(position.Y))*width + int(position.X)
+
+	step := 1
+	if !forward {
+		step = total - 1
+	}
+	for ix := (start + step) % total; ix != start; ix = (ix + step) % total {
+		candidate := types.Position{X: uint16(ix % width), Y: uint16(height - 1 - ix/width)}
+		if matches(candidate) {
+			return candidate, true
+		}
+	}
+	return position, false
+}
+
+func getTile(tiles Tiles, position types.Position) tilecontent.TileContent {
+	return tiles[position.Y][position.X]
+}
+
+func getNeighbours(tiles Tiles, position types.Position) []types.Position {
+	var neighbours []types.Position
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			x, y := int(position.X)+dx, int(position.Y)+dy
+			if (dx == 0 && dy == 0) || y < 0 || y >= len(tiles) || x < 0 || x >= len(tiles[y]) {
+				continue
+			}
+			neighbours = append(neighbours, types.Position{X: uint16(x), Y: uint16(y)})
+		}
+	}
+	return neighbours
+}
@@ -0,0 +1,78 @@
+package motions
+
+import (
+	"testing"
+
+	tilecontent "sweep/shared/consts/tile-content"
+	types "sweep/shared/types"
+)
+
+var (
+	e  = tilecontent.Empty
+	f  = tilecontent.Flag
+	z  = tilecontent.Zero
+	o  = tilecontent.One
+	tw = tilecontent.Two
+)
+
+// Rows are indexed from the bottom, so the last row here is the top one
+var field = Tiles{
+	{z, z, o, e},
+	{o, o, tw, e},
+	{e, f, e, e},
+}
+
+func Test_NextClosedInRow(t *testing.T) {
+	type TestCase struct {
+		position types.Position
+		forward  bool
+		expected types.Position
+		ok       bool
+	}
+
+	testCases := []TestCase{
+		{position: types.Position{X: 0, Y: 2}, forward: true, expected: types.Position{X: 2, Y: 2}, ok: true},
+		{position: types.Position{X: 3, Y: 2}, forward: false, expected: types.Position{X: 2, Y: 2}, ok: true},
+		{position: types.Position{X: 3, Y: 0}, forward: true, expected: types.Position{X: 3, Y: 0}, ok: false},
+		{position: types.Position{X: 3, Y: 0}, forward: false, expected: types.Position{X: 3, Y: 0}, ok: false},
+	}
+
+	for n, testCase := range testCases {
+		actual, ok := NextClosedInRow(field, testCase.position, testCase.forward)
+		if actual != testCase.expected || ok != testCase.ok {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v %v, actual: %v %v", n+1, testCase.expected, testCase.ok, actual, ok)
+		}
+	}
+}
+
+func Test_NextFrontier(t *testing.T) {
+	// Every closed tile of the field touches a number, so the search goes in the reading order
+	position, ok := NextFrontier(field, types.Position{X: 0, Y: 2}, true)
+	if !ok || position != (types.Position{X: 2, Y: 2}) {
+		t.Errorf("[Assertion failed] expected 2:2, got %v %v", position, ok)
+	}
+
+	// Searching back wraps around to the bottom right corner
+	position, ok = NextFrontier(field, types.Position{X: 0, Y: 2}, false)
+	if !ok || position != (types.Position{X: 3, Y: 0}) {
+		t.Errorf("[Assertion failed] expected 3:0, got %v %v", position, ok)
+	}
+
+	opened := Tiles{{z, z}, {z, z}}
+	if _, ok := NextFrontier(opened, types.Position{}, true); ok {
+		t.Errorf("[Assertion failed] expected no frontier on an open field")
+	}
+}
+
+func Test_NextUnsatisfied(t *testing.T) {
+	// The ones next to the flag are satisfied, the two is not
+	position, ok := NextUnsatisfied(field, types.Position{X: 0, Y: 2}, true)
+	if !ok || position != (types.Position{X: 2, Y: 1}) {
+		t.Errorf("[Assertion failed] expected 2:1, got %v %v", position, ok)
+	}
+
+	position, ok = NextUnsatisfied(field, types.Position{X: 2, Y: 1}, true)
+	if !ok || position != (types.Position{X: 2, Y: 0}) {
+		t.Errorf("[Assertion failed] expected 2:0, got %v %v", position, ok)
+	}
+}
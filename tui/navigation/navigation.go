@@ -0,0 +1,80 @@
+package navigation
+
+import (
+	actions "sweep/shared/consts/actions"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Screens ask to be left with these messages, the program running them decides where they lead
+// The app catches them before the screen, screens run as separate programs handle them themselves
+
+// Quits the program
+type ExitMsg struct{}
+
+func Exit() tea.Msg {
+	return ExitMsg{}
+}
+
+// Goes back to the main menu
+type MainMenuMsg struct{}
+
+func MainMenu() tea.Msg {
+	return MainMenuMsg{}
+}
+
+// Starts a new field with the same options
+type NewGameMsg struct{}
+
+// Starts the same field over with the same seed
+type RestartMsg struct{}
+
+// Sent by the game once it is over with the screen showing how it ended
+type GameOverMsg struct {
+	EndScreen tea.Model
+}
+
+// Resumes the game left unfinished on quitting
+type ContinueMsg struct{}
+
+// Plays back the moves of the save
+type ReplayMsg struct {
+	Name string
+}
+
+type Screen string
+
+// Screens opened from the main menu
+const (
+	StartScreen    Screen = "start"
+	StatsScreen    Screen = "stats"
+	SettingsScreen Screen = "settings"
+	ReplaysScreen  Screen = "replays"
+)
+
+type ShowMsg struct {
+	Screen Screen
+}
+
+func Show(screen Screen) tea.Cmd {
+	return func() tea.Msg { return ShowMsg{screen} }
+}
+
+// Maps the global actions leaving the screen to their messages
+// The second return value is false for the actions that stay on the screen
+func FromAction(kind actions.ActionType) (tea.Cmd, bool) {
+	var msg tea.Msg
+	switch kind {
+	case actions.Quit:
+		msg = ExitMsg{}
+	case actions.MainMenu:
+		msg = MainMenuMsg{}
+	case actions.NewGame:
+		msg = NewGameMsg{}
+	case actions.RestartSameBoard:
+		msg = RestartMsg{}
+	default:
+		return nil, false
+	}
+	return func() tea.Msg { return msg }, true
+}
@@ -0,0 +1,182 @@
+package racelobby
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	config "sweep/config"
+	race "sweep/race"
+	actions "sweep/shared/consts/actions"
+	misc "sweep/shared/consts/misc"
+	navigation "sweep/tui/navigation"
+	standings "sweep/tui/standings"
+	styles "sweep/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type standingsMsg []race.Progress
+
+type HostLeftError struct{}
+
+func (e *HostLeftError) Error() string {
+	return "the host has left the race"
+}
+
+func (e *HostLeftError) Is(target error) bool {
+	return e.Error() == target.Error()
+}
+
+type startMsg struct {
+	game race.Game
+	ok   bool
+}
+
+func waitForStandings(peer race.Peer) tea.Cmd {
+	return func() tea.Msg {
+		return standingsMsg(<-peer.Standings())
+	}
+}
+
+func waitForStart(client *race.Client) tea.Cmd {
+	return func() tea.Msg {
+		game, ok := <-client.Started()
+		return startMsg{game, ok}
+	}
+}
+
+// Lobby is shown until the host starts the race
+// Once it is started the config is set up for the race field
+type model struct {
+	config    *config.Config
+	peer      race.Peer
+	host      *race.Host
+	client    *race.Client
+	standings []race.Progress
+	address   string
+	isStarted bool
+	err       error
+}
+
+var _ tea.Model = model{}
+
+func CreateHostModel(config *config.Config, host *race.Host) model {
+	return model{
+		config:  config,
+		peer:    host,
+		host:    host,
+		address: host.GetAddress(),
+	}
+}
+
+func CreateClientModel(config *config.Config, client *race.Client) model {
+	return model{
+		config:  config,
+		peer:    client,
+		client:  client,
+		address: config.RaceJoin,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	cmds := []tea.Cmd{tea.SetWindowTitle(misc.AppName), waitForStandings(m.peer)}
+	if m.client != nil {
+		cmds = append(cmds, waitForStart(m.client))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Everyone plays alone by the rules of the host
+func (m model) applyGame(game race.Game) {
+	m.config.Width = game.Width
+	m.config.Height = game.Height
+	m.config.Mines = game.Mines
+	m.config.Seed = game.Seed
+	m.config.Lives = game.Lives
+	m.config.TimeLimit = game.TimeLimit
+	m.config.TimeBonus = game.TimeBonus
+	m.config.WinCondition = game.WinCondition
+	m.config.NoFlag = game.NoFlag
+	m.config.Players = 0
+}
+
+// Tells if the lobby ended with the start of the race rather than by quitting it or losing the host
+func IsStarted(lobby tea.Model) (bool, error) {
+	m, ok := lobby.(model)
+	if !ok {
+		return false, nil
+	}
+	return m.isStarted, m.err
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	// The program of the lobby ends with it so the peer is closed
+	case navigation.ExitMsg:
+		return m, tea.Quit
+
+	case standingsMsg:
+		m.standings = msg
+		return m, waitForStandings(m.peer)
+
+	case startMsg:
+		if !msg.ok {
+			m.err = &HostLeftError{}
+			return m, tea.Quit
+		}
+		m.applyGame(msg.game)
+		m.isStarted = true
+		return m, tea.Quit
+
+	case tea.KeyMsg:
+		// The lobby is the start screen of the race
+		if kind, ok := actions.GetScreenAction(actions.StartScreenContext, msg.String()); ok && kind == actions.Quit {
+			return m, navigation.Exit
+		}
+		switch msg.String() {
+		case "enter":
+			if m.host == nil {
+				return m, nil
+			}
+			game := race.Game{
+				Seed:         time.Now().UnixNano(),
+				Width:        m.config.Width,
+				Height:       m.config.Height,
+				Mines:        m.config.Mines,
+				Lives:        m.config.Lives,
+				TimeLimit:    m.config.TimeLimit,
+				TimeBonus:    m.config.TimeBonus,
+				WinCondition: m.config.WinCondition,
+				NoFlag:       m.config.NoFlag,
+			}
+			if err := m.host.Start(game); err != nil {
+				return m, nil
+			}
+			m.applyGame(game)
+			m.isStarted = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m model) View() string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render(misc.AppAsciiLogo))
+	s.WriteRune('\n')
+
+	if m.host != nil {
+		fmt.Fprintf(&s, "hosting a race at %v\n", m.address)
+		fmt.Fprintf(&s, "field %vx%v with %v mines\n\n", m.config.Width, m.config.Height, m.config.Mines)
+		s.WriteString(styles.BrightText.Render("press enter to start"))
+	} else {
+		fmt.Fprintf(&s, "joined a race at %v\n\n", m.address)
+		s.WriteString(styles.DimText.Render("waiting for the host to start"))
+	}
+	s.WriteRune('\n')
+	s.WriteString(standings.RenderStandings(m.standings, m.peer.GetID()))
+
+	return s.String()
+}
@@ -0,0 +1,84 @@
+package settings
+
+import (
+	"strconv"
+
+	config "sweep/config"
+	densities "sweep/shared/consts/densities"
+	winconditions "sweep/shared/consts/win-conditions"
+	menu "sweep/tui/menu"
+	navigation "sweep/tui/navigation"
+	styles "sweep/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Lives are stepped one by one so there is no point in going further
+const maxLives = 99
+
+const hint = "the options are kept for the next games until the program is closed like the ones set with :set\nno flag plays with the classic win condition as the strict one needs every mine flagged"
+
+var densityOrder = []densities.Density{densities.Compact, densities.Normal, densities.Large}
+
+var winConditionOrder = []winconditions.WinCondition{winconditions.Classic, winconditions.Strict}
+
+// Steps through the values wrapping around their ends, an unknown value starts from the first one
+func cycle[T comparable](values []T, current T, step int) T {
+	ix := 0
+	for jx, value := range values {
+		if value == current {
+			ix = jx
+		}
+	}
+	return values[(ix+step+len(values))%len(values)]
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "on"
+	}
+	return "off"
+}
+
+// Changes the options of the config the next games are created with
+func CreateModel(conf *config.Config) tea.Model {
+	items := []menu.Item{
+		{
+			Title:  "density",
+			Value:  func() string { return string(conf.Density) },
+			Change: func(step int) { conf.Density = cycle(densityOrder, conf.Density, step) },
+		},
+		{
+			Title: "win condition",
+			Value: func() string { return string(conf.WinCondition) },
+			Change: func(step int) {
+				conf.WinCondition = cycle(winConditionOrder, conf.WinCondition, step)
+				conf.NoFlag = conf.NoFlag && conf.WinCondition != winconditions.Strict
+			},
+		},
+		{
+			Title: "lives",
+			Value: func() string { return strconv.FormatUint(uint64(max(conf.Lives, 1)), 10) },
+			Change: func(step int) {
+				conf.Lives = uint16(min(max(int(max(conf.Lives, 1))+step, 1), maxLives))
+			},
+		},
+		{
+			Title: "no flag",
+			Value: func() string { return formatBool(conf.NoFlag) },
+			Change: func(_ int) {
+				conf.NoFlag = !conf.NoFlag
+				if conf.NoFlag {
+					conf.WinCondition = winconditions.Classic
+				}
+			},
+		},
+		{
+			Title:  "mark gutter",
+			Value:  func() string { return formatBool(conf.MarkGutter) },
+			Change: func(_ int) { conf.MarkGutter = !conf.MarkGutter },
+		},
+		{Title: "back", Cmd: navigation.MainMenu},
+	}
+	return menu.CreateModel("settings", items).WithBody(styles.DimText.Render(hint))
+}
@@ -0,0 +1,37 @@
+package standings
+
+import (
+	"fmt"
+	"strings"
+
+	race "sweep/race"
+	"sweep/shared/utils"
+	styles "sweep/tui/styles"
+)
+
+// Renders progress of every player in the race, the local player is marked with ">"
+func RenderStandings(standings []race.Progress, localID int) string {
+	var s strings.Builder
+	s.WriteString(styles.HeaderStyle.Render("race"))
+
+	for _, progress := range standings {
+		marker := " "
+		if progress.ID == localID {
+			marker = ">"
+		}
+
+		var status string
+		switch {
+		case !progress.Alive:
+			status = styles.WarningText.Render("dead")
+		case progress.Finished:
+			status = utils.FormatTime(progress.Duration)
+		default:
+			status = fmt.Sprintf("%v%%", progress.Percent)
+		}
+
+		fmt.Fprintf(&s, "\n%v %v %v", marker, progress.Name, status)
+	}
+
+	return styles.TableStyle.Render(s.String())
+}
@@ -3,12 +3,13 @@ package startscreen
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
 	config "sweep/config"
+	actions "sweep/shared/consts/actions"
 	misc "sweep/shared/consts/misc"
+	navigation "sweep/tui/navigation"
 	styles "sweep/tui/styles"
 
 	cursor "github.com/charmbracelet/bubbles/cursor"
@@ -142,14 +143,22 @@ func (m *model) validateInputs() {
 	}
 }
 
+// Keys typed into the inputs are never taken for the bindings
+func isInputKey(key string) bool {
+	switch key {
+	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9",
+		"backspace", "delete",
+		"right", "left":
+		return true
+	default:
+		return false
+	}
+}
+
 func (m model) updateInputs(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9",
-			"backspace", "delete",
-			"right", "left":
-
+		if isInputKey(msg.String()) {
 			cmds := make([]tea.Cmd, len(m.inputs))
 
 			for i := range m.inputs {
@@ -164,13 +173,28 @@ func (m model) updateInputs(msg tea.Msg) tea.Cmd {
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	// The app catches them before the screen, the programs of the races end with the field picked or without it
+	case navigation.ExitMsg, navigation.NewGameMsg:
+		return m, tea.Quit
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			os.Exit(0)
+		s := msg.String()
+		// The keys moving the cursor in the game move between the inputs as the arrows do
+		if !isInputKey(s) {
+			kind, _ := actions.GetScreenAction(actions.StartScreenContext, s)
+			switch kind {
+			case actions.Quit:
+				return m, navigation.Exit
+			case actions.MainMenu, actions.ClearKeyBuffer:
+				return m, navigation.MainMenu
+			case actions.MoveCursorUp:
+				s = "up"
+			case actions.MoveCursorDown:
+				s = "down"
+			}
+		}
 
-		case "tab", "shift+tab", "enter", "up", "down", "k", "j":
-			s := msg.String()
+		switch s {
+		case "tab", "shift+tab", "enter", "up", "down":
 
 			if s == "enter" && m.focusIndex == len(m.inputs) {
 				if !m.isValid {
@@ -188,10 +212,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.config.Height = uint16(height)
 				m.config.Mines = uint16(mines)
 
-				return m, tea.Quit
+				return m, func() tea.Msg { return navigation.NewGameMsg{} }
 			}
 
-			if s == "up" || s == "shift+tab" || s == "k" {
+			if s == "up" || s == "shift+tab" {
 				m.focusIndex--
 			} else {
 				m.focusIndex++
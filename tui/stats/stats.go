@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	history "sweep/history"
+	utils "sweep/shared/utils"
+	menu "sweep/tui/menu"
+	navigation "sweep/tui/navigation"
+	styles "sweep/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const noTime = "-"
+
+func formatBest(best time.Duration) string {
+	if best == 0 {
+		return noTime
+	}
+	return utils.FormatTime(best)
+}
+
+// Sums up the history with a row for every field played on
+func Render(h history.History) string {
+	if len(h) == 0 {
+		return "no games played yet"
+	}
+
+	won, noFlags := 0, 0
+	for _, record := range h {
+		if record.Won {
+			won++
+		}
+		if record.Won && record.NoFlags {
+			noFlags++
+		}
+	}
+
+	rows := [][]string{{"field", "played", "won", "best with flags", "best with no flags"}}
+	for _, field := range h.GetFieldStats() {
+		rows = append(rows, []string{
+			fmt.Sprintf("%vx%v/%v", field.Width, field.Height, field.Mines),
+			fmt.Sprint(field.Played),
+			fmt.Sprint(field.Won),
+			formatBest(field.Best),
+			formatBest(field.BestNoFlags),
+		})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for ix, cell := range row {
+			widths[ix] = max(widths[ix], len(cell))
+		}
+	}
+
+	var s strings.Builder
+	fmt.Fprintf(&s, "played %v, won %v (%v%%), won with no flags %v\n", len(h), won, won*100/len(h), noFlags)
+	for ix, row := range rows {
+		cells := make([]string, len(row))
+		for jx, cell := range row {
+			cells[jx] = fmt.Sprintf("%-*v", widths[jx], cell)
+		}
+		line := strings.TrimRight(strings.Join(cells, "  "), " ")
+		if ix == 0 {
+			line = styles.DimText.Render(line)
+		}
+		s.WriteString("\n" + line)
+	}
+	return s.String()
+}
+
+// The history is read every time the screen is opened so the games just played are counted
+func CreateModel() tea.Model {
+	var body string
+	if h, err := history.Load(); err != nil {
+		body = styles.WarningText.Render(err.Error())
+	} else {
+		body = Render(h)
+	}
+	return menu.CreateModel("stats", []menu.Item{{Title: "back", Cmd: navigation.MainMenu}}).WithBody(body)
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	history "sweep/history"
+)
+
+func Test_Render(t *testing.T) {
+	type TestCase struct {
+		history  history.History
+		expected []string
+	}
+
+	testCases := []TestCase{
+		{
+			history:  history.History{},
+			expected: []string{"no games played yet"},
+		},
+		{
+			history: history.History{
+				{Width: 9, Height: 9, Mines: 10, Won: true, Duration: 10 * time.Second},
+				{Width: 9, Height: 9, Mines: 10, Won: false, Duration: 2 * time.Second},
+				{Width: 30, Height: 16, Mines: 99, Won: false, Duration: 5 * time.Second},
+			},
+			expected: []string{"played 3, won 1 (33%), won with no flags 0", "9x9/10", "30x16/99", "00:00:10,00"},
+		},
+	}
+
+	for n, testCase := range testCases {
+		rendered := Render(testCase.history)
+		for _, expected := range testCase.expected {
+			if !strings.Contains(rendered, expected) {
+				t.Errorf("[Assertion failed] #%v\nexpected to contain: %v\nactual:\n%v", n+1, expected, rendered)
+			}
+		}
+	}
+}
@@ -22,6 +22,11 @@ func Center(width int, str string) string {
 	return noStyle.Width(width).AlignHorizontal(lipgloss.Center).Render(str)
 }
 
+// Places the panels next to each other aligned by their top
+func SideBySide(panels ...string) string {
+	return lipgloss.JoinHorizontal(lipgloss.Top, panels...)
+}
+
 var (
 	tileStyle   = noStyle.Bold(true).Foreground(adaptiveColor).Background(lipgloss.NoColor{})
 	noStyle     = lipgloss.NewStyle()
@@ -40,8 +45,9 @@ var (
 	IsFillSet        = false
 	isCursorStyleSet = false
 
-	DimText    = zeroStyle
-	BrightText = sevenStyle
+	DimText     = zeroStyle
+	BrightText  = sevenStyle
+	WarningText = noStyle.Bold(true).Foreground(lipgloss.Color("9"))
 
 	BorderTop    = noStyle.Border(lipgloss.RoundedBorder(), true, false, false, false)
 	BorderBottom = noStyle.Border(lipgloss.RoundedBorder(), false, false, true, false)
@@ -77,6 +83,14 @@ var (
 	cursorStyle    TileStyle = tileStyle
 )
 
+// Colors of players in hot-seat games in the order of turns
+var playerColors = []string{"12", "9", "10", "13"}
+
+func GetPlayerStyle(player int) *TileStyle {
+	style := CreateTileStyle(playerColors[player%len(playerColors)])
+	return &style
+}
+
 func SetFill(fill bool) {
 	if fill {
 		tileStyle = tileStyle.Background(adaptiveColor).Foreground(lipgloss.NoColor{})
@@ -84,6 +98,12 @@ func SetFill(fill bool) {
 	IsFillSet = fill
 }
 
+// Paints the tile with the color of its glyph the way the fill option paints every tile
+func Fill(style *TileStyle) *TileStyle {
+	filled := style.Background(style.GetForeground()).Foreground(reverseAdaptiveColor)
+	return &filled
+}
+
 func SetCursorColor(color string) {
 	isCursorStyleSet = true
 	cursorColor = color
@@ -2,7 +2,9 @@ package tilerenderer
 
 import (
 	"fmt"
+	"strings"
 
+	densities "sweep/shared/consts/densities"
 	tilecontent "sweep/shared/consts/tile-content"
 	tiles "sweep/shared/consts/tiles"
 	types "sweep/shared/types"
@@ -10,11 +12,77 @@ import (
 	styles "sweep/tui/styles"
 )
 
+// Amount of terminal cells taken by a rendered tile, the content with a cursor half on each side
+const TileWidth = 3
+
+// Returns the width in cells and the height in lines of a tile rendered with the density
+func GetTileSize(density densities.Density) (int, int) {
+	switch density {
+	case densities.Compact:
+		return 1, 1
+	case densities.Large:
+		return 5, 3
+	default:
+		return TileWidth, 1
+	}
+}
+
+// Looks set for a single game with :set on top of the ones every game of the program has
+type Looks struct {
+	Fill  bool
+	ASCII bool
+}
+
+func (l Looks) getGlyph(tileContent tilecontent.TileContent) string {
+	if l.ASCII {
+		return tileContent.ASCII()
+	}
+	return tileContent.String()
+}
+
+func (l Looks) getStyle(style *styles.TileStyle) *styles.TileStyle {
+	if l.Fill && !styles.IsFillSet {
+		return styles.Fill(style)
+	}
+	return style
+}
+
+// Renders the tile as as many lines as the density takes
+func (l Looks) RenderTileLines(tileContent tilecontent.TileContent, style *styles.TileStyle, isFocused bool, density densities.Density) []string {
+	glyph := l.getGlyph(tileContent)
+	style = l.getStyle(style)
+	switch density {
+	case densities.Compact:
+		if isFocused {
+			return []string{style.Reverse(true).Render(glyph)}
+		}
+		return []string{style.Render(glyph)}
+	case densities.Large:
+		width, _ := GetTileSize(density)
+		padding := style.Render(strings.Repeat(" ", width))
+		space := style.Render(" ")
+		return []string{
+			padding,
+			space + renderGlyph(glyph, style, isFocused) + space,
+			padding,
+		}
+	default:
+		return []string{renderGlyph(glyph, style, isFocused)}
+	}
+}
+
 func RenderTileByContent(tileContent tilecontent.TileContent, isFocused bool) string {
-	style := styles.GetTileStyle(tileContent)
+	return RenderTileWithStyle(tileContent, styles.GetTileStyle(tileContent), isFocused)
+}
+
+func RenderTileWithStyle(tileContent tilecontent.TileContent, style *styles.TileStyle, isFocused bool) string {
+	return renderGlyph(tileContent.String(), style, isFocused)
+}
+
+func renderGlyph(glyph string, style *styles.TileStyle, isFocused bool) string {
 	template := style.Render("%v%v%v")
 
-	stringTileContent := style.Render(tileContent.String())
+	stringTileContent := style.Render(glyph)
 
 	leftCursorHalf := style.Render(" ")
 	rightCursorHalf := leftCursorHalf
@@ -26,7 +94,7 @@ func RenderTileByContent(tileContent tilecontent.TileContent, isFocused bool) st
 	return fmt.Sprintf(template, leftCursorHalf, stringTileContent, rightCursorHalf)
 }
 
-func RenderTileByType(tile types.Tile, tileContent tilecontent.TileContent) string {
+func (l Looks) RenderTileByType(tile types.Tile, tileContent tilecontent.TileContent) string {
 	switch tile {
 	case tiles.ClosedMine, tiles.OpenMine:
 		tileContent = tilecontent.Mine
@@ -35,5 +103,6 @@ func RenderTileByType(tile types.Tile, tileContent tilecontent.TileContent) stri
 	case tiles.FlaggedSafe:
 		tileContent = tilecontent.WrongFlag
 	}
-	return RenderTileByContent(tileContent, false)
+	style := l.getStyle(styles.GetTileStyle(tileContent))
+	return renderGlyph(l.getGlyph(tileContent), style, false)
 }
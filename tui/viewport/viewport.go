@@ -0,0 +1,113 @@
+package viewport
+
+// The part of the field that fits on the screen
+// Columns and rows are counted from the top left corner of the field
+type Viewport struct {
+	// The first visible column and row
+	Column uint16
+	Row    uint16
+	// The amount of visible columns and rows
+	Width  uint16
+	Height uint16
+	// How close the cursor can get to the edge of the screen before it scrolls
+	Margin uint16
+
+	fieldWidth  uint16
+	fieldHeight uint16
+}
+
+// Shows the whole field until it is resized
+func CreateViewport(fieldWidth, fieldHeight, margin uint16) Viewport {
+	return Viewport{
+		Width:       fieldWidth,
+		Height:      fieldHeight,
+		Margin:      margin,
+		fieldWidth:  fieldWidth,
+		fieldHeight: fieldHeight,
+	}
+}
+
+// Takes the amount of columns and rows that fit on the screen
+func (v *Viewport) Resize(width, height uint16) {
+	v.Width = max(min(width, v.fieldWidth), 1)
+	v.Height = max(min(height, v.fieldHeight), 1)
+	v.Column = clampOffset(int(v.Column), v.Width, v.fieldWidth)
+	v.Row = clampOffset(int(v.Row), v.Height, v.fieldHeight)
+}
+
+// Scrolls just enough to keep the position away from the edges by the margin
+func (v *Viewport) Follow(column, row uint16) {
+	v.Column = follow(v.Column, v.Width, v.fieldWidth, v.Margin, column)
+	v.Row = follow(v.Row, v.Height, v.fieldHeight, v.Margin, row)
+}
+
+func (v *Viewport) Center(column, row uint16) {
+	v.Column = clampOffset(int(column)-int(v.Width)/2, v.Width, v.fieldWidth)
+	v.Row = clampOffset(int(row)-int(v.Height)/2, v.Height, v.fieldHeight)
+}
+
+// Negative values scroll to the left and up
+func (v *Viewport) Scroll(columns, rows int) {
+	v.Column = clampOffset(int(v.Column)+columns, v.Width, v.fieldWidth)
+	v.Row = clampOffset(int(v.Row)+rows, v.Height, v.fieldHeight)
+}
+
+// Brings the position inside the visible part keeping the margin
+func (v Viewport) Clamp(column, row uint16) (uint16, uint16) {
+	return clamp(v.Column, v.Width, v.fieldWidth, v.Margin, column),
+		clamp(v.Row, v.Height, v.fieldHeight, v.Margin, row)
+}
+
+func (v Viewport) IsVisible(column, row uint16) bool {
+	return column >= v.Column && column < v.Column+v.Width &&
+		row >= v.Row && row < v.Row+v.Height
+}
+
+func (v Viewport) HasMoreLeft() bool {
+	return v.Column > 0
+}
+
+func (v Viewport) HasMoreRight() bool {
+	return v.Column+v.Width < v.fieldWidth
+}
+
+func (v Viewport) HasMoreAbove() bool {
+	return v.Row > 0
+}
+
+func (v Viewport) HasMoreBelow() bool {
+	return v.Row+v.Height < v.fieldHeight
+}
+
+// The margin can not take more than half of the screen or the position would never settle
+func getMargin(margin, size uint16) uint16 {
+	return min(margin, (max(size, 1)-1)/2)
+}
+
+func clampOffset(offset int, size, total uint16) uint16 {
+	return uint16(max(min(offset, int(total)-int(size)), 0))
+}
+
+func follow(offset, size, total, margin, position uint16) uint16 {
+	margin = getMargin(margin, size)
+	next := int(offset)
+	if int(position) < next+int(margin) {
+		next = int(position) - int(margin)
+	} else if int(position)+int(margin) >= next+int(size) {
+		next = int(position) + int(margin) + 1 - int(size)
+	}
+	return clampOffset(next, size, total)
+}
+
+func clamp(offset, size, total, margin, position uint16) uint16 {
+	margin = getMargin(margin, size)
+	low := int(offset) + int(margin)
+	if offset == 0 {
+		low = 0
+	}
+	high := int(offset) + int(size) - 1 - int(margin)
+	if int(offset)+int(size) >= int(total) {
+		high = int(total) - 1
+	}
+	return uint16(max(min(int(position), high), low))
+}
@@ -0,0 +1,111 @@
+package viewport
+
+import "testing"
+
+func Test_Follow(t *testing.T) {
+	type TestCase struct {
+		name           string
+		viewport       Viewport
+		column, row    uint16
+		expectedColumn uint16
+		expectedRow    uint16
+	}
+
+	testCases := []TestCase{
+		{
+			name:           "position inside the margin does not scroll",
+			viewport:       Viewport{Width: 10, Height: 10, Margin: 2, fieldWidth: 30, fieldHeight: 30},
+			column:         5,
+			row:            7,
+			expectedColumn: 0,
+			expectedRow:    0,
+		},
+		{
+			name:           "position past the margin scrolls forward",
+			viewport:       Viewport{Width: 10, Height: 10, Margin: 2, fieldWidth: 30, fieldHeight: 30},
+			column:         8,
+			row:            9,
+			expectedColumn: 1,
+			expectedRow:    2,
+		},
+		{
+			name:           "position before the margin scrolls back",
+			viewport:       Viewport{Column: 10, Row: 10, Width: 10, Height: 10, Margin: 2, fieldWidth: 30, fieldHeight: 30},
+			column:         11,
+			row:            10,
+			expectedColumn: 9,
+			expectedRow:    8,
+		},
+		{
+			name:           "scrolling stops at the edge of the field",
+			viewport:       Viewport{Width: 10, Height: 10, Margin: 2, fieldWidth: 30, fieldHeight: 30},
+			column:         29,
+			row:            0,
+			expectedColumn: 20,
+			expectedRow:    0,
+		},
+		{
+			name:           "margin is limited by the size of the screen",
+			viewport:       Viewport{Width: 3, Height: 3, Margin: 5, fieldWidth: 30, fieldHeight: 30},
+			column:         10,
+			row:            10,
+			expectedColumn: 9,
+			expectedRow:    9,
+		},
+	}
+
+	for _, testCase := range testCases {
+		viewport := testCase.viewport
+		viewport.Follow(testCase.column, testCase.row)
+		if viewport.Column != testCase.expectedColumn || viewport.Row != testCase.expectedRow {
+			t.Errorf("[Assertion failed] %v: expected %v:%v, got %v:%v", testCase.name,
+				testCase.expectedColumn, testCase.expectedRow, viewport.Column, viewport.Row)
+		}
+	}
+}
+
+func Test_Scroll(t *testing.T) {
+	viewport := CreateViewport(30, 20, 2)
+	viewport.Resize(10, 10)
+
+	viewport.Scroll(5, -5)
+	if viewport.Column != 5 || viewport.Row != 0 {
+		t.Errorf("[Assertion failed] expected 5:0, got %v:%v", viewport.Column, viewport.Row)
+	}
+
+	viewport.Scroll(100, 100)
+	if viewport.Column != 20 || viewport.Row != 10 {
+		t.Errorf("[Assertion failed] expected 20:10, got %v:%v", viewport.Column, viewport.Row)
+	}
+	if viewport.HasMoreRight() || viewport.HasMoreBelow() || !viewport.HasMoreLeft() || !viewport.HasMoreAbove() {
+		t.Errorf("[Assertion failed] expected the field to continue only to the left and above")
+	}
+
+	column, row := viewport.Clamp(0, 29)
+	if column != 22 || row != 19 {
+		t.Errorf("[Assertion failed] expected the position to be clamped to 22:19, got %v:%v", column, row)
+	}
+}
+
+func Test_Center(t *testing.T) {
+	viewport := CreateViewport(30, 20, 0)
+	viewport.Resize(10, 5)
+
+	viewport.Center(15, 10)
+	if viewport.Column != 10 || viewport.Row != 8 {
+		t.Errorf("[Assertion failed] expected 10:8, got %v:%v", viewport.Column, viewport.Row)
+	}
+
+	viewport.Center(1, 19)
+	if viewport.Column != 0 || viewport.Row != 15 {
+		t.Errorf("[Assertion failed] expected 0:15, got %v:%v", viewport.Column, viewport.Row)
+	}
+}
+
+func Test_Resize(t *testing.T) {
+	viewport := CreateViewport(8, 8, 0)
+	viewport.Resize(100, 0)
+	if viewport.Width != 8 || viewport.Height != 1 {
+		t.Errorf("[Assertion failed] expected 8x1, got %vx%v", viewport.Width, viewport.Height)
+	}
+}
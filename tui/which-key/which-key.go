@@ -0,0 +1,37 @@
+package whichkey
+
+import (
+	"fmt"
+	"strings"
+
+	actions "sweep/shared/consts/actions"
+	styles "sweep/tui/styles"
+)
+
+// Lists the bindings that could complete the keys typed so far like the which-key plugin of VIM
+// Bindings past the line limit are counted in the last line
+func Render(completions []actions.Binding, maxLines int) string {
+	maxLines = max(maxLines, 1)
+	if len(completions) > maxLines {
+		hidden := len(completions) - maxLines + 1
+		return render(completions[:maxLines-1], fmt.Sprintf("and %v more", hidden))
+	}
+	return render(completions, "")
+}
+
+func render(completions []actions.Binding, more string) string {
+	keysWidth := 0
+	for _, completion := range completions {
+		keysWidth = max(keysWidth, len(completion.Keys))
+	}
+
+	var lines []string
+	for _, completion := range completions {
+		keys := styles.HeaderStyle.Render(completion.Keys + strings.Repeat(" ", keysWidth-len(completion.Keys)))
+		lines = append(lines, fmt.Sprintf("%v → %v", keys, completion.Kind))
+	}
+	if more != "" {
+		lines = append(lines, styles.DimText.Render(more))
+	}
+	return styles.TableStyle.Render(strings.Join(lines, "\n"))
+}
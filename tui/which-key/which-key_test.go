@@ -0,0 +1,42 @@
+package whichkey
+
+import (
+	"strings"
+	"testing"
+
+	actions "sweep/shared/consts/actions"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func Test_Render(t *testing.T) {
+	type TestCase struct {
+		completions []actions.Binding
+		maxLines    int
+		lines       int
+		more        bool
+	}
+
+	completions := []actions.Binding{
+		{Keys: "g", Kind: actions.OpenTile},
+		{Keys: "gc", Kind: actions.CenterCursor},
+		{Keys: "gg", Kind: actions.MoveCursorToTopRow},
+	}
+
+	testCases := []TestCase{
+		{completions: completions, maxLines: 10, lines: 3, more: false},
+		{completions: completions, maxLines: 3, lines: 3, more: false},
+		{completions: completions, maxLines: 2, lines: 2, more: true},
+		{completions: completions, maxLines: 0, lines: 1, more: true},
+	}
+
+	for n, testCase := range testCases {
+		rendered := Render(testCase.completions, testCase.maxLines)
+		// The border takes a line above and below the list
+		lines := lipgloss.Height(rendered) - 2
+		more := strings.Contains(rendered, "more")
+		if lines != testCase.lines || more != testCase.more {
+			t.Errorf("[Assertion failed] #%v\nexpected: %v lines %v, actual: %v lines %v", n+1, testCase.lines, testCase.more, lines, more)
+		}
+	}
+}